@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	address := "localhost:50051"
+	if len(os.Args) > 1 {
+		address = os.Args[1]
+	}
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	response, err := pb.NewGovoxClient(conn).Backup(ctx, &pb.BackupRequest{})
+	if err != nil {
+		log.Fatalf("backup failed: %v", err)
+	}
+	fmt.Println(response.Path)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+	"github.com/jeffbaumes/govox/pkg/server"
+)
+
+const usage = `usage:
+	govox world export <world> <archive>
+	govox world import <archive> <world>
+	govox world vox <world> <planet> <lon> <lat> <alt> <radius> <file.vox>`
+
+// intArg parses a command line argument as an integer
+func intArg(arg string) int64 {
+	v, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		log.Fatalf("%v is not an integer\n%v", arg, usage)
+	}
+	return v
+}
+
+func main() {
+	if len(os.Args) < 3 || os.Args[1] != "world" {
+		log.Fatal(usage)
+	}
+	switch {
+	case os.Args[2] == "export" && len(os.Args) == 5:
+		server.ExportWorld(os.Args[3], os.Args[4])
+	case os.Args[2] == "import" && len(os.Args) == 5:
+		server.ImportWorld(os.Args[3], os.Args[4])
+	case os.Args[2] == "vox" && len(os.Args) == 10:
+		center := pb.CellIndex{Lon: intArg(os.Args[5]), Lat: intArg(os.Args[6]), Alt: intArg(os.Args[7])}
+		server.ExportVox(os.Args[3], intArg(os.Args[4]), center, intArg(os.Args[8]), os.Args[9])
+	default:
+		log.Fatal(usage)
+	}
+}
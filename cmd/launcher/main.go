@@ -209,7 +209,7 @@ func main() {
 			if profiles[ui.profile].world == "" {
 				client.Start(profiles[ui.profile].name, profiles[ui.profile].host, profiles[ui.profile].port, screen)
 			} else if profiles[ui.profile].world != "" {
-				go server.Start(profiles[ui.profile].world, 123, profiles[ui.profile].port, "sun-moon")
+				go server.Start(profiles[ui.profile].world, 123, profiles[ui.profile].port, "sun-moon", server.DefaultOptions)
 				time.Sleep(time.Second)
 				client.Start(profiles[ui.profile].name, profiles[ui.profile].host, profiles[ui.profile].port, screen)
 			}
@@ -227,6 +227,7 @@ func main() {
 		glfw.PollEvents()
 		w.SwapBuffers()
 	}
+	server.Stop()
 }
 
 func writefile(t string) {
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jeffbaumes/govox/pkg/common"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+	"google.golang.org/grpc"
+)
+
+const usage = "usage: place <structure.vox|structure.json> <planet> <lon> <lat> <alt> [quarter turns] [address]"
+
+// intArg parses a command line argument as an integer
+func intArg(arg string) int64 {
+	v, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		log.Fatalf("%v is not an integer\n%v", arg, usage)
+	}
+	return v
+}
+
+func main() {
+	if len(os.Args) < 6 || len(os.Args) > 8 {
+		log.Fatal(usage)
+	}
+	voxels, err := common.ReadStructure(os.Args[1])
+	if err != nil {
+		log.Fatalf("failed to read structure: %v", err)
+	}
+	request := pb.PlaceStructureRequest{
+		Planet: intArg(os.Args[2]),
+		Origin: &pb.CellIndex{Lon: intArg(os.Args[3]), Lat: intArg(os.Args[4]), Alt: intArg(os.Args[5])},
+		Voxels: voxels,
+	}
+	if len(os.Args) > 6 {
+		request.Turns = intArg(os.Args[6])
+	}
+	address := "localhost:50051"
+	if len(os.Args) > 7 {
+		address = os.Args[7]
+	}
+
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	response, err := pb.NewGovoxClient(conn).PlaceStructure(ctx, &request)
+	if err != nil {
+		log.Fatalf("place structure failed: %v", err)
+	}
+	fmt.Printf("changed %v cells\n", response.Changed)
+}
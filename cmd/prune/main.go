@@ -0,0 +1,15 @@
+package main
+
+import (
+	"os"
+
+	"github.com/jeffbaumes/govox/pkg/server"
+)
+
+func main() {
+	name := "default"
+	if len(os.Args) > 1 {
+		name = os.Args[1]
+	}
+	server.Prune(name)
+}
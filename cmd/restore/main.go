@@ -0,0 +1,15 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/jeffbaumes/govox/pkg/server"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatalf("usage: %v <world> <backup>", os.Args[0])
+	}
+	server.Restore(os.Args[1], os.Args[2])
+}
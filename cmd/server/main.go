@@ -1,9 +1,16 @@
 package main
 
 import (
+	"flag"
+
 	"github.com/jeffbaumes/govox/pkg/server"
 )
 
 func main() {
-	server.Start("default", 0, 50051, "default")
+	options := server.DefaultOptions
+	flag.DurationVar(&options.SaveLatency, "save-latency", options.SaveLatency, "longest a changed chunk waits before it is saved")
+	flag.DurationVar(&options.BackupInterval, "backup-interval", options.BackupInterval, "time between backups of the world, or 0 for none")
+	flag.IntVar(&options.BackupRetention, "backup-retention", options.BackupRetention, "number of backups of the world to keep")
+	flag.Parse()
+	server.Start("default", 0, 50051, "default", options)
 }
@@ -231,75 +231,59 @@ func keyCallbackPlay(w *glfw.Window, key glfw.Key, scancode int, action glfw.Act
 			player.Planet = universe.PlanetMap[id].Planet
 			player.Spawn()
 		case m["Destroy"].Key:
-			increment := player.LookDir().Mul(0.05)
-			pos := player.Location()
-			for i := 0; i < 100; i++ {
-				pos = pos.Add(increment)
-				cell := planet.CartesianToCell(pos)
-				hitPlayer := false
-				for _, otherPlayer := range universe.ConnectedPeople {
-					if pos.Sub(otherPlayer.Position).Len() < 0.6 {
-						log.Println(fmt.Sprintf("Hit %v", otherPlayer.Name))
-						ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-						defer cancel()
-						universe.GRPCClient.HitPlayer(ctx, &pb.HitPlayerRequest{
-							From:   player.Name,
-							Target: otherPlayer.Name,
-							Amount: 1,
-						})
-						hitPlayer = true
+			origin := player.Location()
+			dir := player.LookDir()
+			hit, ok := planet.Raycast(origin, dir, player.Reach)
+			reach := player.Reach
+			if ok {
+				reach = hit.Distance
+			}
+			hitPlayer := false
+			for _, otherPlayer := range universe.ConnectedPeople {
+				// Hit the player if the ray passes near them before reaching a cell
+				along := otherPlayer.Position.Sub(origin).Dot(dir)
+				if along < 0 || along > reach || origin.Add(dir.Mul(along)).Sub(otherPlayer.Position).Len() >= 0.6 {
+					continue
+				}
+				log.Println(fmt.Sprintf("Hit %v", otherPlayer.Name))
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				universe.GRPCClient.HitPlayer(ctx, &pb.HitPlayerRequest{
+					From:   player.Name,
+					Target: otherPlayer.Name,
+					Amount: 1,
+				})
+				hitPlayer = true
+				break
+			}
+			if !hitPlayer && ok {
+				bestSpot := -1
+				for i, slot := range player.Hotbar {
+					if slot.Material == hit.Cell.Material {
+						bestSpot = i
 						break
 					}
-				}
-				if hitPlayer {
-					break
-				}
-				if cell != nil && cell.Material != pb.Material_AIR {
-					bestSpot := -1
-					for i, slot := range player.Hotbar {
-						if slot.Material == cell.Material {
-							bestSpot = i
-							break
-						}
-						if slot.Material == pb.Material_AIR && bestSpot < 0 {
-							bestSpot = i
-						}
+					if slot.Material == pb.Material_AIR && bestSpot < 0 {
+						bestSpot = i
 					}
-					if bestSpot >= 0 {
-						player.Hotbar[bestSpot] = common.Slot{
-							Material: cell.Material,
-							Amount:   player.Hotbar[bestSpot].Amount + 1,
-						}
+				}
+				if bestSpot >= 0 {
+					player.Hotbar[bestSpot] = common.Slot{
+						Material: hit.Cell.Material,
+						Amount:   player.Hotbar[bestSpot].Amount + 1,
 					}
-					cellIndex := planet.CartesianToCellIndex(pos)
-					planetRen.SetCellMaterial(cellIndex, pb.Material_AIR, true)
-					break
 				}
+				planetRen.SetCellMaterial(hit.Index, pb.Material_AIR, true)
 			}
 		case m["Build"].Key:
-			increment := player.LookDir().Mul(0.05)
-			pos := player.Location()
-			prevCellIndex := pb.CellIndex{Lon: -1, Lat: -1, Alt: -1}
-			cellIndex := pb.CellIndex{}
-			for i := 0; i < 100; i++ {
-				pos = pos.Add(increment)
-				nextCellIndex := planet.CartesianToCellIndex(pos)
-				if nextCellIndex.Lon != cellIndex.Lon || nextCellIndex.Lat != cellIndex.Lat || nextCellIndex.Alt != cellIndex.Alt {
-					prevCellIndex = cellIndex
-					cellIndex = nextCellIndex
-					cell := planet.CellIndexToCell(cellIndex)
-					if cell != nil && cell.Material != pb.Material_AIR {
-						if prevCellIndex.Lon != -1 {
-							hotbarslot := player.Hotbar[player.ActiveHotBarSlot]
-							player.Hotbar[player.ActiveHotBarSlot].Amount--
-							if hotbarslot.Amount == 1 {
-								player.Hotbar[player.ActiveHotBarSlot] = common.Slot{}
-							}
-							planetRen.SetCellMaterial(prevCellIndex, hotbarslot.Material, true)
-						}
-						break
-					}
+			hit, ok := planet.Raycast(player.Location(), player.LookDir(), player.Reach)
+			if ok && hit.HasPrevious {
+				hotbarslot := player.Hotbar[player.ActiveHotBarSlot]
+				player.Hotbar[player.ActiveHotBarSlot].Amount--
+				if hotbarslot.Amount == 1 {
+					player.Hotbar[player.ActiveHotBarSlot] = common.Slot{}
 				}
+				planetRen.SetCellMaterial(hit.Previous, hotbarslot.Material, true)
 			}
 		}
 	case glfw.Release:
@@ -64,11 +64,17 @@ func Start(username, host string, port int, scr *gui.Screen) {
 	}
 	log.Printf("Planets: %v", planetsResult.Planets)
 
+	planets := []*common.Planet{}
 	for _, spec := range planetsResult.Planets {
+		if err := common.ValidatePlanetSpec(spec); err != nil {
+			log.Fatalf("server sent a bad planet: %v", err)
+		}
 		planet := common.NewPlanet(grpcClient, nil, *spec)
 		planetRen := scene.NewPlanet(planet)
 		universe.AddPlanet(planetRen)
+		planets = append(planets, planet)
 	}
+	player.Universe = common.NewClientUniverse(planets)
 
 	op = scene.NewOptions(screen)
 	player.Planet = universe.PlanetMap[0].Planet
@@ -102,15 +108,16 @@ func Start(username, host string, port int, scr *gui.Screen) {
 	startTime := time.Now()
 	t := startTime
 	syncT := t
+	sim := common.NewSimulation(player, 0)
 	for !window.ShouldClose() {
 		h := float32(time.Since(t)) / float32(time.Second)
 		t = time.Now()
 		elapsedSeconds := float64(time.Since(startTime)) / float64(time.Second)
 
+		sim.Advance(elapsedSeconds)
+		sim.Interpolate(elapsedSeconds)
 		drawFrame(h, player, text, over, peopleRen, focusRen, bar, health, screen, elapsedSeconds, op)
 
-		player.UpdatePosition(h)
-
 		if float64(time.Since(syncT))/float64(time.Second) > 0.05 {
 			syncT = time.Now()
 			request := pb.UpdatePlayerStateRequest{
@@ -0,0 +1,137 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// Climate thresholds used to pick a biome, with temperature and moisture in [0, 1]
+const (
+	polarTemperature    = 0.15
+	tundraTemperature   = 0.3
+	desertTemperature   = 0.65
+	desertMoisture      = 0.35
+	badlandsTemperature = 0.5
+	badlandsMoisture    = 0.45
+	forestMoisture      = 0.6
+	beachHeight         = 1.5
+)
+
+// BiomeMaterials holds the materials that make up the top layers of a biome
+type BiomeMaterials struct {
+	Surface         pb.Material
+	Subsurface      pb.Material
+	SubsurfaceDepth int
+}
+
+// BiomeLayers maps each biome to its surface and subsurface materials
+var BiomeLayers = map[pb.Biome]BiomeMaterials{
+	pb.Biome_NO_BIOME:  {Surface: pb.Material_GRASS, Subsurface: pb.Material_DIRT, SubsurfaceDepth: 3},
+	pb.Biome_OCEAN:     {Surface: pb.Material_YELLOW_SAND, Subsurface: pb.Material_STONE, SubsurfaceDepth: 0},
+	pb.Biome_BEACH:     {Surface: pb.Material_YELLOW_SAND, Subsurface: pb.Material_YELLOW_SAND, SubsurfaceDepth: 3},
+	pb.Biome_GRASSLAND: {Surface: pb.Material_GRASS, Subsurface: pb.Material_DIRT, SubsurfaceDepth: 3},
+	pb.Biome_FOREST:    {Surface: pb.Material_GRASS, Subsurface: pb.Material_DIRT, SubsurfaceDepth: 4},
+	pb.Biome_DESERT:    {Surface: pb.Material_YELLOW_SAND, Subsurface: pb.Material_YELLOW_BLOCK, SubsurfaceDepth: 5},
+	pb.Biome_BADLANDS:  {Surface: pb.Material_RED_SAND, Subsurface: pb.Material_RED_BLOCK, SubsurfaceDepth: 6},
+	pb.Biome_TUNDRA:    {Surface: pb.Material_PURPLE_SAND, Subsurface: pb.Material_DIRT, SubsurfaceDepth: 2},
+	pb.Biome_POLAR:     {Surface: pb.Material_BLUE_SAND, Subsurface: pb.Material_BLUE_BLOCK, SubsurfaceDepth: 4},
+}
+
+// maxBiomeDepth is the deepest any biome's layers reach below the surface
+var maxBiomeDepth = func() int {
+	depth := 0
+	for _, layers := range BiomeLayers {
+		depth = Max(depth, layers.SubsurfaceDepth+1)
+	}
+	return depth
+}()
+
+var biomeGenerators map[string](func(*Planet, pb.CellLoc) pb.Biome)
+
+func init() {
+	biomeGenerators = make(map[string](func(*Planet, pb.CellLoc) pb.Biome))
+	biomeGenerators["biomes"] = func(p *Planet, loc pb.CellLoc) pb.Biome {
+		return p.climateBiome(loc, p.SurfaceHeight(loc))
+	}
+}
+
+// Latitude returns the latitude in degrees of a location, from -90 at the south pole to 90 at the north pole
+func (p *Planet) Latitude(l pb.CellLoc) float64 {
+	return p.Topology.Latitude(l)
+}
+
+// surfacePoint returns the world coordinates of the top of the column of a location, scaled so that the top
+// of a sphere's cells lies at a distance from its center. Noise sampled there does not change with altitude.
+func (p *Planet) surfacePoint(l pb.CellLoc, radius float64) mgl32.Vec3 {
+	l.Alt = float64(p.Spec.AltCells)
+	return p.CellLocToCartesian(l).Mul(float32(radius / p.Spec.Radius))
+}
+
+// SeaLevel returns the altitude below which open ground is flooded
+func (p *Planet) SeaLevel() float64 {
+	return float64(p.Spec.AltCells)/2 + 1
+}
+
+// SurfaceHeight returns the terrain height at the longitude and latitude of a location
+func (p *Planet) SurfaceHeight(l pb.CellLoc) float64 {
+	pos := p.surfacePoint(l, float64(p.Spec.AltCells/2))
+	const scale = 0.1
+	return float64(p.Spec.AltCells)/2 + p.noise.Eval3(float64(pos[0])*scale, float64(pos[1])*scale, float64(pos[2])*scale)*terrainRelief
+}
+
+// Temperature returns the temperature in [0, 1] of the surface at a location given its terrain height.
+// It is hottest at the equator and falls off towards the poles and with elevation.
+func (p *Planet) Temperature(l pb.CellLoc, height float64) float64 {
+	pos := p.surfacePoint(l, p.Spec.Radius)
+	const scale = 0.03
+	n := p.noise.Eval3(float64(pos[0])*scale+100, float64(pos[1])*scale, float64(pos[2])*scale)
+	t := 0.85*math.Cos(p.Latitude(l)*math.Pi/180) + 0.25*n
+	t -= 0.02 * math.Max(height-p.SeaLevel(), 0)
+	return math.Max(0, math.Min(1, t))
+}
+
+// Moisture returns the moisture in [0, 1] of the surface at a location
+func (p *Planet) Moisture(l pb.CellLoc) float64 {
+	pos := p.surfacePoint(l, p.Spec.Radius)
+	const scale = 0.04
+	n := p.noise.Eval3(float64(pos[0])*scale, float64(pos[1])*scale+200, float64(pos[2])*scale)
+	return math.Max(0, math.Min(1, 0.5+0.6*n))
+}
+
+// BiomeAt returns the biome at the longitude and latitude of a location
+func (p *Planet) BiomeAt(l pb.CellLoc) pb.Biome {
+	if p.BiomeGenerator == nil {
+		return pb.Biome_NO_BIOME
+	}
+	return p.BiomeGenerator(p, l)
+}
+
+func (p *Planet) climateBiome(l pb.CellLoc, height float64) pb.Biome {
+	temperature := p.Temperature(l, height)
+	if temperature < polarTemperature {
+		return pb.Biome_POLAR
+	}
+	sea := p.SeaLevel()
+	if height < sea-1 {
+		return pb.Biome_OCEAN
+	}
+	if height < sea-1+beachHeight {
+		return pb.Biome_BEACH
+	}
+	if temperature < tundraTemperature {
+		return pb.Biome_TUNDRA
+	}
+	moisture := p.Moisture(l)
+	if temperature > desertTemperature && moisture < desertMoisture {
+		return pb.Biome_DESERT
+	}
+	if temperature > badlandsTemperature && moisture < badlandsMoisture {
+		return pb.Biome_BADLANDS
+	}
+	if moisture > forestMoisture {
+		return pb.Biome_FOREST
+	}
+	return pb.Biome_GRASSLAND
+}
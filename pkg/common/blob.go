@@ -0,0 +1,117 @@
+package common
+
+import (
+	"bytes"
+	"compress/zlib"
+	"database/sql"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// blobMagic starts every stored blob, telling it apart from the gob blobs stored before there were versions.
+// A gob stream cannot start with these bytes, since its first message always defines a type.
+var blobMagic = []byte("GVOX")
+
+// blobVersion is the version of newly stored blobs. Version 1 is zlib-compressed protobuf.
+const blobVersion = 1
+
+// encodeBlob stores a message as a versioned, compressed protobuf
+func encodeBlob(m proto.Message) []byte {
+	data, e := proto.Marshal(m)
+	if e != nil {
+		panic(e)
+	}
+	var buf bytes.Buffer
+	buf.Write(blobMagic)
+	buf.WriteByte(blobVersion)
+	w := zlib.NewWriter(&buf)
+	_, e = w.Write(data)
+	if e != nil {
+		panic(e)
+	}
+	e = w.Close()
+	if e != nil {
+		panic(e)
+	}
+	return buf.Bytes()
+}
+
+// decodeBlob reads a message stored by encodeBlob
+func decodeBlob(data []byte, m proto.Message) {
+	if isLegacyBlob(data) {
+		panic("blob is gob encoded and needs migrating")
+	}
+	version := data[len(blobMagic)]
+	if version != blobVersion {
+		panic(fmt.Sprintf("unknown blob version %d", version))
+	}
+	r, e := zlib.NewReader(bytes.NewReader(data[len(blobMagic)+1:]))
+	if e != nil {
+		panic(e)
+	}
+	defer r.Close()
+	raw, e := ioutil.ReadAll(r)
+	if e != nil {
+		panic(e)
+	}
+	e = proto.Unmarshal(raw, m)
+	if e != nil {
+		panic(e)
+	}
+}
+
+// isLegacyBlob reports whether a blob was stored with gob, before blobs had versions
+func isLegacyBlob(data []byte) bool {
+	return len(data) <= len(blobMagic) || !bytes.HasPrefix(data, blobMagic)
+}
+
+// MigrateLegacyBlobs converts the gob blobs in the chunk and planet tables of a world database to versioned protobuf, in place
+func MigrateLegacyBlobs(tx *sql.Tx) error {
+	if err := migrateLegacyTable(tx, "planet", func() proto.Message { return &pb.PlanetSpec{} }); err != nil {
+		return err
+	}
+	return migrateLegacyTable(tx, "chunk", func() proto.Message { return &pb.Chunk{} })
+}
+
+func migrateLegacyTable(tx *sql.Tx, table string, newMessage func() proto.Message) error {
+	converted, err := convertLegacyRows(tx, table, newMessage)
+	if err != nil {
+		return err
+	}
+	for id, data := range converted {
+		if _, err := tx.Exec("UPDATE "+table+" SET data = ? WHERE rowid = ?", data, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertLegacyRows reads the gob blobs of a table and returns them as versioned protobuf by row id
+func convertLegacyRows(tx *sql.Tx, table string, newMessage func() proto.Message) (map[int64][]byte, error) {
+	rows, err := tx.Query("SELECT rowid, data FROM " + table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	converted := map[int64][]byte{}
+	for rows.Next() {
+		var id int64
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, err
+		}
+		if !isLegacyBlob(data) {
+			continue
+		}
+		m := newMessage()
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(m); err != nil {
+			return nil, fmt.Errorf("%v row %v: %v", table, id, err)
+		}
+		converted[id] = encodeBlob(m)
+	}
+	return converted, rows.Err()
+}
@@ -0,0 +1,285 @@
+package common
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// ChunkStore persists the chunks of the planets in a universe, keyed by planet id and chunk key
+type ChunkStore interface {
+	// Load returns a stored chunk, or nil if the chunk has not been stored
+	Load(planet int64, key ChunkKey) *pb.Chunk
+	// Save stores a chunk, replacing any stored before
+	Save(planet int64, key ChunkKey, chunk *pb.Chunk)
+	// SaveBatch stores many chunks at once, replacing any stored before
+	SaveBatch(planet int64, chunks map[ChunkKey]*pb.Chunk)
+	// Delete removes a stored chunk, if there is one
+	Delete(planet int64, key ChunkKey)
+	// List returns the keys of every stored chunk of a planet
+	List(planet int64) []ChunkKey
+}
+
+func encodeChunk(chunk *pb.Chunk) []byte {
+	return encodeBlob(chunk)
+}
+
+func decodeChunk(data []byte) *pb.Chunk {
+	var chunk pb.Chunk
+	decodeBlob(data, &chunk)
+	return &chunk
+}
+
+// SQLiteChunkStore stores chunks in the chunk table of a sqlite database
+type SQLiteChunkStore struct {
+	db    *sql.DB
+	mutex sync.Mutex
+}
+
+// NewSQLiteChunkStore creates a chunk store in a database, creating its table if needed
+func NewSQLiteChunkStore(db *sql.DB) *SQLiteChunkStore {
+	_, e := db.Exec("CREATE TABLE IF NOT EXISTS chunk (planet INT, lon INT, lat INT, alt INT, data BLOB, PRIMARY KEY (planet, lat, lon, alt))")
+	if e != nil {
+		panic(e)
+	}
+	return &SQLiteChunkStore{db: db}
+}
+
+// Load returns a stored chunk, or nil if the chunk has not been stored
+func (s *SQLiteChunkStore) Load(planet int64, key ChunkKey) *pb.Chunk {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var data []byte
+	e := s.db.QueryRow("SELECT data FROM chunk WHERE planet = ? AND lon = ? AND lat = ? AND alt = ?", planet, key.Lon, key.Lat, key.Alt).Scan(&data)
+	if e == sql.ErrNoRows {
+		return nil
+	}
+	if e != nil {
+		panic(e)
+	}
+	return decodeChunk(data)
+}
+
+// Save stores a chunk, replacing any stored before
+func (s *SQLiteChunkStore) Save(planet int64, key ChunkKey, chunk *pb.Chunk) {
+	data := encodeChunk(chunk)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, e := s.db.Exec("INSERT OR REPLACE INTO chunk VALUES (?, ?, ?, ?, ?)", planet, key.Lon, key.Lat, key.Alt, data)
+	if e != nil {
+		panic(e)
+	}
+}
+
+// SaveBatch stores many chunks in one transaction, replacing any stored before
+func (s *SQLiteChunkStore) SaveBatch(planet int64, chunks map[ChunkKey]*pb.Chunk) {
+	data := make(map[ChunkKey][]byte, len(chunks))
+	for key, chunk := range chunks {
+		data[key] = encodeChunk(chunk)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tx, e := s.db.Begin()
+	if e != nil {
+		panic(e)
+	}
+	stmt, e := tx.Prepare("INSERT OR REPLACE INTO chunk VALUES (?, ?, ?, ?, ?)")
+	if e != nil {
+		panic(e)
+	}
+	defer stmt.Close()
+	for key, d := range data {
+		_, e = stmt.Exec(planet, key.Lon, key.Lat, key.Alt, d)
+		if e != nil {
+			panic(e)
+		}
+	}
+	e = tx.Commit()
+	if e != nil {
+		panic(e)
+	}
+}
+
+// Delete removes a stored chunk, if there is one
+func (s *SQLiteChunkStore) Delete(planet int64, key ChunkKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, e := s.db.Exec("DELETE FROM chunk WHERE planet = ? AND lon = ? AND lat = ? AND alt = ?", planet, key.Lon, key.Lat, key.Alt)
+	if e != nil {
+		panic(e)
+	}
+}
+
+// List returns the keys of every stored chunk of a planet
+func (s *SQLiteChunkStore) List(planet int64) []ChunkKey {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	rows, e := s.db.Query("SELECT lon, lat, alt FROM chunk WHERE planet = ?", planet)
+	if e != nil {
+		panic(e)
+	}
+	defer rows.Close()
+	keys := []ChunkKey{}
+	for rows.Next() {
+		var key ChunkKey
+		e = rows.Scan(&key.Lon, &key.Lat, &key.Alt)
+		if e != nil {
+			panic(e)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// MemoryChunkStore keeps chunks in memory, for worlds that need not outlive the process
+type MemoryChunkStore struct {
+	chunks map[int64]map[ChunkKey][]byte
+	mutex  sync.Mutex
+}
+
+// NewMemoryChunkStore creates an empty in-memory chunk store
+func NewMemoryChunkStore() *MemoryChunkStore {
+	return &MemoryChunkStore{chunks: make(map[int64]map[ChunkKey][]byte)}
+}
+
+// Load returns a stored chunk, or nil if the chunk has not been stored
+func (s *MemoryChunkStore) Load(planet int64, key ChunkKey) *pb.Chunk {
+	s.mutex.Lock()
+	data, ok := s.chunks[planet][key]
+	s.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+	return decodeChunk(data)
+}
+
+// Save stores a chunk, replacing any stored before.
+// The chunk is stored encoded, so later changes to it are not seen until it is saved again.
+func (s *MemoryChunkStore) Save(planet int64, key ChunkKey, chunk *pb.Chunk) {
+	data := encodeChunk(chunk)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.chunks[planet] == nil {
+		s.chunks[planet] = make(map[ChunkKey][]byte)
+	}
+	s.chunks[planet][key] = data
+}
+
+// SaveBatch stores many chunks at once, replacing any stored before
+func (s *MemoryChunkStore) SaveBatch(planet int64, chunks map[ChunkKey]*pb.Chunk) {
+	for key, chunk := range chunks {
+		s.Save(planet, key, chunk)
+	}
+}
+
+// Delete removes a stored chunk, if there is one
+func (s *MemoryChunkStore) Delete(planet int64, key ChunkKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.chunks[planet], key)
+}
+
+// List returns the keys of every stored chunk of a planet
+func (s *MemoryChunkStore) List(planet int64) []ChunkKey {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	keys := []ChunkKey{}
+	for key := range s.chunks[planet] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// FileChunkStore stores each chunk in a file of its own, in a directory for each planet
+type FileChunkStore struct {
+	dir string
+}
+
+// NewFileChunkStore creates a chunk store in a directory, creating the directory if needed
+func NewFileChunkStore(dir string) *FileChunkStore {
+	e := os.MkdirAll(dir, os.ModePerm)
+	if e != nil {
+		panic(e)
+	}
+	return &FileChunkStore{dir: dir}
+}
+
+func (s *FileChunkStore) planetDir(planet int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(planet, 10))
+}
+
+func (s *FileChunkStore) path(planet int64, key ChunkKey) string {
+	return filepath.Join(s.planetDir(planet), fmt.Sprintf("%d_%d_%d.chunk", key.Lon, key.Lat, key.Alt))
+}
+
+// Load returns a stored chunk, or nil if the chunk has not been stored
+func (s *FileChunkStore) Load(planet int64, key ChunkKey) *pb.Chunk {
+	data, e := ioutil.ReadFile(s.path(planet, key))
+	if os.IsNotExist(e) {
+		return nil
+	}
+	if e != nil {
+		panic(e)
+	}
+	return decodeChunk(data)
+}
+
+// Save stores a chunk, replacing any stored before.
+// The chunk is written beside its file and then moved over it, so a crash never leaves half a chunk behind.
+func (s *FileChunkStore) Save(planet int64, key ChunkKey, chunk *pb.Chunk) {
+	e := os.MkdirAll(s.planetDir(planet), os.ModePerm)
+	if e != nil {
+		panic(e)
+	}
+	path := s.path(planet, key)
+	e = ioutil.WriteFile(path+".tmp", encodeChunk(chunk), 0644)
+	if e != nil {
+		panic(e)
+	}
+	e = os.Rename(path+".tmp", path)
+	if e != nil {
+		panic(e)
+	}
+}
+
+// SaveBatch stores many chunks at once, replacing any stored before
+func (s *FileChunkStore) SaveBatch(planet int64, chunks map[ChunkKey]*pb.Chunk) {
+	for key, chunk := range chunks {
+		s.Save(planet, key, chunk)
+	}
+}
+
+// Delete removes a stored chunk, if there is one
+func (s *FileChunkStore) Delete(planet int64, key ChunkKey) {
+	e := os.Remove(s.path(planet, key))
+	if e != nil && !os.IsNotExist(e) {
+		panic(e)
+	}
+}
+
+// List returns the keys of every stored chunk of a planet
+func (s *FileChunkStore) List(planet int64) []ChunkKey {
+	files, e := ioutil.ReadDir(s.planetDir(planet))
+	if os.IsNotExist(e) {
+		return []ChunkKey{}
+	}
+	if e != nil {
+		panic(e)
+	}
+	keys := []ChunkKey{}
+	for _, f := range files {
+		var key ChunkKey
+		var rest string
+		n, _ := fmt.Sscanf(f.Name(), "%d_%d_%d%s", &key.Lon, &key.Lat, &key.Alt, &rest)
+		if n == 4 && rest == ".chunk" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
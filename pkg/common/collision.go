@@ -0,0 +1,178 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// collisionPasses is the number of times overlaps are resolved after each movement step
+	collisionPasses = 4
+
+	// collisionMargin widens the search for cells near the player beyond the capsule itself,
+	// and is the clearance left above a ledge when stepping up onto it
+	collisionMargin = 0.1
+
+	// overlapTolerance is the depth of overlap with a cell small enough to count as merely touching it
+	overlapTolerance = 1e-3
+
+	// stepSettleIterations is the number of halvings used to find where a player stepping up comes to rest
+	stepSettleIterations = 8
+
+	// groundNormal is the smallest upward component of a contact normal that counts as standing on the ground
+	groundNormal = 0.7
+)
+
+// cellRegion is the region of space covered by a cell
+type cellRegion interface {
+	// push returns the smallest displacement that moves a sphere out of the cell, and whether the two overlap
+	push(center mgl32.Vec3, radius float64) (mgl32.Vec3, bool)
+
+	// exit returns the distance along a ray with unit direction v, past a distance after, at which it leaves
+	// the cell, and the face it leaves through. The face is NoFace if the ray never leaves.
+	exit(o, v [3]float64, after float64) (float64, CellFace)
+}
+
+// pushFromNearest returns the displacement that moves a sphere away from the nearest point of a cell outside it,
+// and whether the two overlap
+func pushFromNearest(center, nearest mgl32.Vec3, radius float64) (mgl32.Vec3, bool) {
+	d := center.Sub(nearest)
+	dist := float64(d.Len())
+	if dist >= radius || dist == 0 {
+		return mgl32.Vec3{}, false
+	}
+	return d.Mul(float32((radius - dist) / dist)), true
+}
+
+// solidCellsNear returns the regions of the solid cells within a distance of a point, listing merged cells once
+func (p *Planet) solidCellsNear(center mgl32.Vec3, reach float64) []cellRegion {
+	return p.Topology.solidCellsNear(center, reach)
+}
+
+// capsuleSpheres returns spheres of the player's radius, spaced closely enough along the player's
+// collision capsule to stand in for it, from the feet to the top of the head
+func (player *Player) capsuleSpheres(p *Planet, loc mgl32.Vec3) []mgl32.Vec3 {
+	up := p.Up(loc)
+	bottom := loc.Sub(up.Mul(float32(player.height - player.radius)))
+	length := player.height - player.radius
+	n := int(math.Ceil(length/(player.radius/2))) + 1
+	spheres := make([]mgl32.Vec3, n)
+	for i := range spheres {
+		spheres[i] = bottom.Add(up.Mul(float32(length * float64(i) / float64(n-1))))
+	}
+	return spheres
+}
+
+// resolveCollisions pushes the player out of any solid cells they overlap and returns the normals of the surfaces touched
+func (player *Player) resolveCollisions(p *Planet) []mgl32.Vec3 {
+	normals := []mgl32.Vec3{}
+	reach := player.height/2 + player.radius + collisionMargin
+	up := p.Up(player.loc)
+	cells := p.solidCellsNear(player.loc.Sub(up.Mul(float32(player.height/2))), reach)
+	for pass := 0; pass < collisionPasses; pass++ {
+		moved := false
+		loc := player.loc
+		for _, sphere := range player.capsuleSpheres(p, loc) {
+			offset := sphere.Sub(loc)
+			for _, cell := range cells {
+				// Each push moves the whole capsule, so find the sphere again from the latest location
+				push, hit := cell.push(player.loc.Add(offset), player.radius)
+				if !hit {
+					continue
+				}
+				player.loc = player.loc.Add(push)
+				normals = append(normals, push.Normalize())
+				moved = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	return normals
+}
+
+// overlapsCells returns whether the player's capsule at a location overlaps any solid cell by more than a sliver
+func (player *Player) overlapsCells(p *Planet, loc mgl32.Vec3) bool {
+	up := p.Up(loc)
+	reach := player.height/2 + player.radius + collisionMargin
+	cells := p.solidCellsNear(loc.Sub(up.Mul(float32(player.height/2))), reach)
+	for _, sphere := range player.capsuleSpheres(p, loc) {
+		for _, cell := range cells {
+			if push, hit := cell.push(sphere, player.radius); hit && push.Len() > overlapTolerance {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// move sweeps the player through a displacement in planet coordinates, sliding along solid cells.
+// Steps are short enough that the player cannot pass through a cell however fast they move.
+// If stepUp is set, walking into a ledge one cell high lifts the player onto it.
+// It returns whether the player ended up standing on the ground and whether their head hit a ceiling.
+func (player *Player) move(p *Planet, delta mgl32.Vec3, stepUp bool) (grounded, ceiling bool) {
+	if player.Mode == "Apex" {
+		return
+	}
+	steps := int(math.Ceil(float64(delta.Len())/(player.radius/2))) + 1
+	step := delta.Mul(1 / float32(steps))
+	for i := 0; i < steps; i++ {
+		start := player.loc
+		up := p.Up(start)
+		player.loc = start.Add(step)
+		normals := player.resolveCollisions(p)
+
+		blocked := false
+		for _, n := range normals {
+			switch d := n.Dot(up); {
+			case d > groundNormal:
+				grounded = true
+			case d < -groundNormal:
+				ceiling = true
+			default:
+				blocked = true
+			}
+		}
+		if !blocked || !stepUp {
+			continue
+		}
+
+		// Retry the step from one cell higher, reaching at least far enough to put the player's feet over a ledge,
+		// and keep it only if the raised player moves freely and comes to rest standing on something
+		across := ProjectToPlane(step, up)
+		if across.Len() == 0 {
+			continue
+		}
+		if across.Len() < float32(player.radius) {
+			across = across.Normalize().Mul(float32(player.radius))
+		}
+		blockedLoc := player.loc
+		raised := start.Add(up.Mul(float32(p.AltDelta + collisionMargin)))
+		if player.overlapsCells(p, raised) || player.overlapsCells(p, raised.Add(across)) {
+			continue
+		}
+		player.loc = raised.Add(across)
+		lo, hi := float32(0), float32(p.AltDelta+collisionMargin)
+		for j := 0; j < stepSettleIterations; j++ {
+			mid := (lo + hi) / 2
+			if player.overlapsCells(p, player.loc.Sub(up.Mul(mid))) {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		player.loc = player.loc.Sub(up.Mul(hi))
+		standing := false
+		for _, n := range player.resolveCollisions(p) {
+			standing = standing || n.Dot(up) > groundNormal
+		}
+		if standing {
+			grounded = true
+		} else {
+			player.loc = blockedLoc
+		}
+	}
+	return
+}
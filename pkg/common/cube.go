@@ -0,0 +1,392 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// cubeFace is a face of a cube-sphere planet, given by its outward normal and the directions of increasing
+// longitude and latitude cell index across it. Like the sphere, latitude runs clockwise from longitude seen from outside.
+type cubeFace struct {
+	n, u, v [3]float64
+}
+
+// cubeFaces are the faces of a cube-sphere planet in the order they are packed along the longitude index.
+// The first four go around the equator, so longitude runs continuously between them as on the sphere.
+var cubeFaces = [6]cubeFace{
+	{n: [3]float64{1, 0, 0}, u: [3]float64{0, 1, 0}, v: [3]float64{0, 0, -1}},
+	{n: [3]float64{0, 1, 0}, u: [3]float64{-1, 0, 0}, v: [3]float64{0, 0, -1}},
+	{n: [3]float64{-1, 0, 0}, u: [3]float64{0, -1, 0}, v: [3]float64{0, 0, -1}},
+	{n: [3]float64{0, -1, 0}, u: [3]float64{1, 0, 0}, v: [3]float64{0, 0, -1}},
+	{n: [3]float64{0, 0, 1}, u: [3]float64{0, 1, 0}, v: [3]float64{1, 0, 0}},
+	{n: [3]float64{0, 0, -1}, u: [3]float64{0, 1, 0}, v: [3]float64{-1, 0, 0}},
+}
+
+func dot3(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func scale3(a [3]float64, s float64) [3]float64 {
+	return [3]float64{a[0] * s, a[1] * s, a[2] * s}
+}
+
+// cubeTopology lays cells out on the six faces of a cube puffed out into a sphere, packed side by side along the
+// longitude index. Cells stay close to square over the whole surface instead of crowding together at poles.
+type cubeTopology struct {
+	p *Planet
+
+	// n is the number of cells along each side of a face
+	n int64
+}
+
+func newCubeTopology(p *Planet) Topology {
+	// Match the cell size of a sphere at the equator, where each face spans a quarter turn
+	n := int64(math.Max(float64(p.ChunkSize), float64(sphereLonCells(p.Spec.Radius, p.ChunkSize)/4/p.ChunkSize*p.ChunkSize)))
+	p.LonCells = 6 * n
+	p.LatCells = n
+	return &cubeTopology{p: p, n: n}
+}
+
+// faceOf returns the face holding a floating-point longitude cell index
+func (c *cubeTopology) faceOf(lon float64) int64 {
+	face := int64(math.Floor((lon + 0.5) / float64(c.n)))
+	return int64(math.Max(0, math.Min(5, float64(face))))
+}
+
+// cellToPlane converts a floating-point cell index across a face to the matching coordinate on the plane
+// tangent to the face center. Cells cover equal angles, which keeps them close to square over the whole face.
+func (c *cubeTopology) cellToPlane(i float64) float64 {
+	a := 2*(i+0.5)/float64(c.n) - 1
+	return math.Tan(a * math.Pi / 4)
+}
+
+// planeToCell converts a coordinate on the plane tangent to a face center to a floating-point cell index across the face
+func (c *cubeTopology) planeToCell(x float64) float64 {
+	a := math.Atan(x) * 4 / math.Pi
+	return (a+1)*float64(c.n)/2 - 0.5
+}
+
+// faceDirection returns the direction from the planet center through floating-point cell indices across a face,
+// scaled to meet the plane tangent to the face center. The indices may run past the edges of the face, extending it.
+func (c *cubeTopology) faceDirection(face int64, u, v float64) [3]float64 {
+	f := cubeFaces[face]
+	x, y := c.cellToPlane(u), c.cellToPlane(v)
+	d := [3]float64{}
+	for i := range d {
+		d[i] = f.n[i] + x*f.u[i] + y*f.v[i]
+	}
+	return d
+}
+
+// faceLocToCartesian converts floating-point cell indices across a face to world coordinates
+func (c *cubeTopology) faceLocToCartesian(face int64, u, v, alt float64) mgl32.Vec3 {
+	p := c.p
+	d := c.faceDirection(face, u, v)
+	r := (alt*p.AltDelta + p.AltMin) / math.Sqrt(dot3(d, d))
+	return mgl32.Vec3{float32(d[0] * r), float32(d[1] * r), float32(d[2] * r)}
+}
+
+// cartesianToFaceLoc converts world coordinates to floating-point cell indices across a face.
+// The point must be on the outer side of the face's plane through the planet center.
+func (c *cubeTopology) cartesianToFaceLoc(face int64, cart mgl32.Vec3) (u, v, alt float64) {
+	p := c.p
+	f := cubeFaces[face]
+	pt := [3]float64{float64(cart[0]), float64(cart[1]), float64(cart[2])}
+	h := dot3(pt, f.n)
+	u = c.planeToCell(dot3(pt, f.u) / h)
+	v = c.planeToCell(dot3(pt, f.v) / h)
+	alt = (math.Sqrt(dot3(pt, pt)) - p.AltMin) / p.AltDelta
+	return
+}
+
+// cubeFaceAt returns the face of a cube-sphere planet that a point lies over
+func cubeFaceAt(cart mgl32.Vec3) int64 {
+	c := [3]float64{float64(cart[0]), float64(cart[1]), float64(cart[2])}
+	best, bestDot := int64(0), math.Inf(-1)
+	for i, f := range cubeFaces {
+		if d := dot3(c, f.n); d > bestDot {
+			best, bestDot = int64(i), d
+		}
+	}
+	return best
+}
+
+func (c *cubeTopology) CellLocToCartesian(l pb.CellLoc) mgl32.Vec3 {
+	face := c.faceOf(l.Lon)
+	return c.faceLocToCartesian(face, l.Lon-float64(face*c.n), l.Lat, l.Alt)
+}
+
+// CellVertexToCartesian places a corner on the edge of a face by the cell's own face
+func (c *cubeTopology) CellVertexToCartesian(ind pb.CellIndex, l pb.CellLoc) mgl32.Vec3 {
+	face := ind.Lon / c.n
+	return c.faceLocToCartesian(face, l.Lon-float64(face*c.n), l.Lat, l.Alt)
+}
+
+func (c *cubeTopology) CartesianToCellLoc(cart mgl32.Vec3) pb.CellLoc {
+	face := cubeFaceAt(cart)
+	u, v, alt := c.cartesianToFaceLoc(face, cart)
+	return pb.CellLoc{Lon: float64(face*c.n) + u, Lat: v, Alt: alt}
+}
+
+// NearestCellCenter keeps to the face holding the location, so points on a face edge land in a cell of that face
+func (c *cubeTopology) NearestCellCenter(l pb.CellLoc) pb.CellLoc {
+	n := float64(c.n)
+	face := float64(c.faceOf(l.Lon))
+	return pb.CellLoc{
+		Lon: face*n + math.Max(0, math.Min(n-1, math.Floor(l.Lon-face*n+0.5))),
+		Lat: math.Max(0, math.Min(n-1, math.Floor(l.Lat+0.5))),
+		Alt: math.Floor(l.Alt + 0.5),
+	}
+}
+
+// NeighborCellIndex takes longitude steps before latitude steps. Steps carry across face edges
+// and keep heading away from the edge they crossed, so the same axis may point a new way on the new face.
+func (c *cubeTopology) NeighborCellIndex(ind pb.CellIndex, dLon, dLat, dAlt int64) (pb.CellIndex, bool) {
+	ind.Alt += dAlt
+	if ind.Alt < 0 || ind.Alt >= c.p.Spec.AltCells {
+		return ind, false
+	}
+
+	n := c.n
+	face := ind.Lon / n
+	u, v := ind.Lon-face*n, ind.Lat
+	f := cubeFaces[face]
+	lonDir, latDir := f.u, f.v
+	if dLon < 0 {
+		lonDir, dLon = scale3(lonDir, -1), -dLon
+	}
+	if dLat < 0 {
+		latDir, dLat = scale3(latDir, -1), -dLat
+	}
+	moves := []struct {
+		dir   *[3]float64
+		count int64
+	}{{&lonDir, dLon}, {&latDir, dLat}}
+	for _, m := range moves {
+		for i := int64(0); i < m.count; i++ {
+			f := cubeFaces[face]
+			du, dv := int64(dot3(*m.dir, f.u)), int64(dot3(*m.dir, f.v))
+			if u+du >= 0 && u+du < n && v+dv >= 0 && v+dv < n {
+				u, v = u+du, v+dv
+				continue
+			}
+
+			// Cross the edge to the face the step points at, landing on the cells along the shared edge.
+			// Further steps head away from the face just left.
+			edgeU, edgeV := float64(u), float64(v)
+			if du != 0 {
+				edgeU = float64(u) + float64(du)/2
+			} else {
+				edgeV = float64(v) + float64(dv)/2
+			}
+			d := c.faceDirection(face, edgeU, edgeV)
+			edge := mgl32.Vec3{float32(d[0]), float32(d[1]), float32(d[2])}
+			for j, g := range cubeFaces {
+				if g.n == *m.dir {
+					face = int64(j)
+				}
+			}
+			*m.dir = scale3(f.n, -1)
+			nu, nv, _ := c.cartesianToFaceLoc(face, edge)
+			u = int64(math.Max(0, math.Min(float64(n-1), math.Floor(nu+0.5))))
+			v = int64(math.Max(0, math.Min(float64(n-1), math.Floor(nv+0.5))))
+		}
+	}
+	ind.Lon, ind.Lat = face*n+u, v
+	return ind, true
+}
+
+// LonLatCellsInChunkIndex keeps cells square away from the core, since a cube-sphere planet has no poles
+func (c *cubeTopology) LonLatCellsInChunkIndex(ind pb.ChunkIndex) (lonCells, latCells int) {
+	return c.p.reduceNearCore(ind, int(c.p.ChunkSize), int(c.p.ChunkSize))
+}
+
+func (c *cubeTopology) Up(cart mgl32.Vec3) mgl32.Vec3 {
+	return cart.Normalize()
+}
+
+func (c *cubeTopology) Latitude(l pb.CellLoc) float64 {
+	_, theta, _ := mgl32.CartesianToSpherical(c.CellLocToCartesian(l))
+	return 90 - float64(theta)*180/math.Pi
+}
+
+// SpawnLoc is at the center of the first face, along the planet's X axis
+func (c *cubeTopology) SpawnLoc() pb.CellLoc {
+	return pb.CellLoc{Lon: float64(c.n)/2 - 0.5, Lat: float64(c.n)/2 - 0.5}
+}
+
+func (c *cubeTopology) Closed() bool {
+	return true
+}
+
+// geometrySampleLoc lays samples out on a grid of longitudes and latitudes running from pole to pole, as on a sphere
+func (c *cubeTopology) geometrySampleLoc(lon, lat, lonSamples, latSamples int) pb.CellLoc {
+	theta := math.Pi * float32(lat) / float32(latSamples-1)
+	phi := 2 * math.Pi * float32(lon) / float32(lonSamples)
+	l := c.CartesianToCellLoc(mgl32.SphericalToCartesian(1, theta, phi))
+	l.Alt = 0
+	return l
+}
+
+// cubeCell is the region of space covered by a cell of a cube-sphere planet, bounded by two spheres and four planes
+// through the planet center. The planes are given by coordinates on the plane tangent to the center of the cell's face.
+type cubeCell struct {
+	n, u, v    [3]float64
+	rMin, rMax float64
+	xMin, xMax float64
+	yMin, yMax float64
+}
+
+func (c *cubeTopology) cellRegion(ind pb.CellIndex) cellRegion {
+	p := c.p
+	lonMin, lonMax, latMin, latMax, altMin, altMax := p.cellBounds(ind)
+	face := ind.Lon / c.n
+	base := float64(face * c.n)
+	f := cubeFaces[face]
+	return cubeCell{
+		n: f.n, u: f.u, v: f.v,
+		rMin: math.Max(0, altMin*p.AltDelta+p.AltMin),
+		rMax: altMax*p.AltDelta + p.AltMin,
+		xMin: c.cellToPlane(lonMin - base),
+		xMax: c.cellToPlane(lonMax - base),
+		yMin: c.cellToPlane(latMin),
+		yMax: c.cellToPlane(latMax),
+	}
+}
+
+func (c cubeCell) push(center mgl32.Vec3, radius float64) (mgl32.Vec3, bool) {
+	pt := [3]float64{float64(center[0]), float64(center[1]), float64(center[2])}
+	h := dot3(pt, c.n)
+	r := math.Sqrt(dot3(pt, pt))
+	if h <= 0 || r == 0 {
+		return mgl32.Vec3{}, false
+	}
+	x, y := dot3(pt, c.u)/h, dot3(pt, c.v)/h
+
+	inside := r >= c.rMin && r <= c.rMax && x >= c.xMin && x <= c.xMax && y >= c.yMin && y <= c.yMax
+	if !inside {
+		// Clamp the sphere center into the cell to find the nearest point of the cell
+		rc := math.Max(c.rMin, math.Min(c.rMax, r))
+		xc := math.Max(c.xMin, math.Min(c.xMax, x))
+		yc := math.Max(c.yMin, math.Min(c.yMax, y))
+		d := [3]float64{}
+		for i := range d {
+			d[i] = c.n[i] + xc*c.u[i] + yc*c.v[i]
+		}
+		s := rc / math.Sqrt(dot3(d, d))
+		return pushFromNearest(center, mgl32.Vec3{float32(d[0] * s), float32(d[1] * s), float32(d[2] * s)}, radius)
+	}
+
+	// The center is inside the cell, so leave through the nearest face.
+	// Each side plane has a normal pointing into the cell, along which the center's distance is measured.
+	radial := center.Mul(float32(1 / r))
+	inward := r - c.rMin
+	if c.rMin == 0 {
+		inward = math.Inf(1)
+	}
+	dir, dist := radial, c.rMax-r
+	if inward < dist {
+		dir, dist = radial.Mul(-1), inward
+	}
+	for _, side := range []struct {
+		axis [3]float64
+		at   float64
+		sign float64
+	}{{c.u, c.xMin, 1}, {c.u, c.xMax, -1}, {c.v, c.yMin, 1}, {c.v, c.yMax, -1}} {
+		m := [3]float64{}
+		for i := range m {
+			m[i] = side.sign * (side.axis[i] - side.at*c.n[i])
+		}
+		length := math.Sqrt(dot3(m, m))
+		if d := dot3(pt, m) / length; d < dist {
+			dir = mgl32.Vec3{float32(-m[0] / length), float32(-m[1] / length), float32(-m[2] / length)}
+			dist = d
+		}
+	}
+	return dir.Mul(float32(dist + radius)), true
+}
+
+// exit finds the sides of the cell on planes through the planet center
+func (c cubeCell) exit(o, v [3]float64, after float64) (float64, CellFace) {
+	e := newRayExit(after)
+	for _, b := range []struct {
+		r    float64
+		face CellFace
+	}{{c.rMin, AltMinFace}, {c.rMax, AltMaxFace}} {
+		if b.r <= 0 {
+			continue
+		}
+		for _, t := range sphereIntersections(o, v, b.r) {
+			e.consider(t, b.face)
+		}
+	}
+	for _, b := range []struct {
+		axis [3]float64
+		x    float64
+		face CellFace
+	}{{c.u, c.xMin, LonMinFace}, {c.u, c.xMax, LonMaxFace}, {c.v, c.yMin, LatMinFace}, {c.v, c.yMax, LatMaxFace}} {
+		if t, ok := facePlaneIntersection(o, v, b.axis, c.n, b.x); ok {
+			e.consider(t, b.face)
+		}
+	}
+	return e.t, e.face
+}
+
+// stepAcross enters the new cell through whichever of its sides the exit point is nearest,
+// which may be turned from the exit face across a face edge
+func (c *cubeTopology) stepAcross(ind pb.CellIndex, exit CellFace, pt mgl32.Vec3) (pb.CellIndex, CellFace, bool) {
+	p := c.p
+	n := c.n
+	face := ind.Lon / n
+	lonMin, lonMax, latMin, latMax, _, _ := p.cellBounds(ind)
+	u, v, _ := c.cartesianToFaceLoc(face, pt)
+
+	// Step from the single index of the cell being left next to the exit point, since the cell may span several
+	clampIndex := func(i, lo, hi float64) int64 {
+		return int64(math.Max(lo+0.5, math.Min(hi-0.5, math.Floor(i+0.5))))
+	}
+	base := float64(face * n)
+	sub := pb.CellIndex{Lon: face*n + clampIndex(u, lonMin-base, lonMax-base), Lat: clampIndex(v, latMin, latMax), Alt: ind.Alt}
+	switch exit {
+	case AltMinFace:
+		sub.Alt--
+		return sub, AltMaxFace, true
+	case AltMaxFace:
+		sub.Alt++
+		return sub, AltMinFace, true
+	}
+
+	// Moving across the surface does not depend on altitude, which may be outside the planet
+	next, _, _ := p.gridStepAcross(sub, exit)
+
+	nextFace := next.Lon / n
+	nextBase := float64(nextFace * n)
+	lonMin, lonMax, latMin, latMax, _, _ = p.cellBounds(next)
+	u, v, _ = c.cartesianToFaceLoc(nextFace, pt)
+	entry, best := NoFace, math.Inf(1)
+	for _, s := range []struct {
+		dist float64
+		face CellFace
+	}{
+		{math.Abs(u - (lonMin - nextBase)), LonMinFace},
+		{math.Abs(u - (lonMax - nextBase)), LonMaxFace},
+		{math.Abs(v - latMin), LatMinFace},
+		{math.Abs(v - latMax), LatMaxFace},
+	} {
+		if s.dist < best {
+			entry, best = s.face, s.dist
+		}
+	}
+	return next, entry, true
+}
+
+// solidCellsNear searches outward across the surface from the cell under the point, over face edges where needed
+func (c *cubeTopology) solidCellsNear(center mgl32.Vec3, reach float64) []cellRegion {
+	p := c.p
+
+	// Cells are narrowest at the corners of a face, at half the angle they cover at its center
+	width := math.Max(float64(center.Len())-reach, p.AltDelta) * math.Pi / float64(4*c.n)
+	return p.gridSolidCellsNear(center, reach, width)
+}
@@ -0,0 +1,110 @@
+package common
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+func TestCubePlaneConversion(t *testing.T) {
+	c := testTopologyPlanet(CubeTopology).Topology.(*cubeTopology)
+	n := float64(c.n)
+
+	// The edges of a face meet the edges of the cube, and cells cover equal angles in between
+	if x := c.cellToPlane(-0.5); math.Abs(x+1) > 1e-12 {
+		t.Errorf("first edge of a face is at %v, want -1", x)
+	}
+	if x := c.cellToPlane(n - 0.5); math.Abs(x-1) > 1e-12 {
+		t.Errorf("last edge of a face is at %v, want 1", x)
+	}
+	for i := -0.5; i <= n-0.5; i += 0.25 {
+		if back := c.planeToCell(c.cellToPlane(i)); math.Abs(back-i) > 1e-9 {
+			t.Errorf("cell %v went to the plane and back to %v", i, back)
+		}
+		angle := math.Atan(c.cellToPlane(i+1)) - math.Atan(c.cellToPlane(i))
+		if i+1 <= n-0.5 && math.Abs(angle-math.Pi/2/n) > 1e-9 {
+			t.Errorf("cell %v covers %v radians, want %v", i, angle, math.Pi/2/n)
+		}
+	}
+}
+
+func TestCubeFaceEdges(t *testing.T) {
+	p := testTopologyPlanet(CubeTopology)
+	c := p.Topology.(*cubeTopology)
+	n := c.n
+	edges := map[[2]int64]bool{}
+	alt := p.Spec.AltCells - 1
+	position := func(ind pb.CellIndex) mgl32.Vec3 {
+		return p.CellLocToCartesian(p.CellIndexToCellLoc(ind))
+	}
+	for face := int64(0); face < 6; face++ {
+		f := cubeFaces[face]
+		sides := []struct {
+			dLon, dLat int64
+			u, v       func(i int64) int64
+		}{
+			{-1, 0, func(i int64) int64 { return 0 }, func(i int64) int64 { return i }},
+			{1, 0, func(i int64) int64 { return n - 1 }, func(i int64) int64 { return i }},
+			{0, -1, func(i int64) int64 { return i }, func(i int64) int64 { return 0 }},
+			{0, 1, func(i int64) int64 { return i }, func(i int64) int64 { return n - 1 }},
+		}
+		for _, side := range sides {
+			// The step leaves the face toward the neighboring face whose normal it points along
+			dir := [3]float64{}
+			for k := range dir {
+				dir[k] = float64(side.dLon)*f.u[k] + float64(side.dLat)*f.v[k]
+			}
+			across := int64(-1)
+			for j, g := range cubeFaces {
+				if g.n == dir {
+					across = int64(j)
+				}
+			}
+			if across < 0 {
+				t.Fatalf("face %v has no neighbor along %v", face, dir)
+			}
+			if across < face {
+				edges[[2]int64{across, face}] = true
+			} else {
+				edges[[2]int64{face, across}] = true
+			}
+
+			g := cubeFaces[across]
+			backLon, backLat := int64(dot3(f.n, g.u)), int64(dot3(f.n, g.v))
+			between := mgl32.Vec3{float32(f.n[0] - g.n[0]), float32(f.n[1] - g.n[1]), float32(f.n[2] - g.n[2])}.Normalize()
+			reached := map[[3]int64]bool{}
+			for i := int64(0); i < n; i++ {
+				ind := pb.CellIndex{Lon: face*n + side.u(i), Lat: side.v(i), Alt: alt}
+				next, ok := p.NeighborCellIndex(ind, side.dLon, side.dLat, 0)
+				if !ok || next.Lon/n != across || next.Alt != alt {
+					t.Errorf("face %v: step %v,%v from %v went to %v, %v, want face %v", face, side.dLon, side.dLat, ind, next, ok, across)
+					continue
+				}
+				reached[[3]int64{next.Lon, next.Lat, next.Alt}] = true
+
+				// Stepping back toward the face just left returns to the start
+				if back, ok := p.NeighborCellIndex(next, backLon, backLat, 0); !ok || !sameCell(back, ind) {
+					t.Errorf("face %v: step %v,%v from %v to %v stepped back to %v", face, side.dLon, side.dLat, ind, next, back)
+				}
+
+				// The cells touch across the edge, mirror images of each other across the plane between the faces
+				if k := p.CartesianToCellIndex(position(ind).Add(position(next)).Mul(0.5)); !sameCell(k, ind) && !sameCell(k, next) {
+					t.Errorf("face %v: halfway between %v and %v is in %v", face, ind, next, k)
+				}
+				a := position(ind)
+				mirror := a.Sub(between.Mul(2 * a.Dot(between)))
+				if d := position(next).Sub(mirror).Len(); d > 1e-3 {
+					t.Errorf("face %v: step across the edge from %v to %v is %v from the mirror of the start", face, ind, next, d)
+				}
+			}
+			if int64(len(reached)) != n {
+				t.Errorf("face %v: stepping %v,%v off the edge reached %v cells, want %v", face, side.dLon, side.dLat, len(reached), n)
+			}
+		}
+	}
+	if len(edges) != 12 {
+		t.Errorf("walked %v edges, want 12", len(edges))
+	}
+}
@@ -0,0 +1,194 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// TreeSpecies describes the shape and materials of the trees growing in a biome
+type TreeSpecies struct {
+	Trunk        pb.Material
+	Leaves       pb.Material
+	Density      float64
+	MinHeight    int
+	MaxHeight    int
+	CanopyRadius int
+}
+
+// BiomeTrees maps each biome to the trees that grow in it. Biomes without an entry have no trees.
+// Leaves of AIR make bare trunks.
+var BiomeTrees = map[pb.Biome]TreeSpecies{
+	pb.Biome_FOREST:    {Trunk: pb.Material_GREEN_WOOD, Leaves: pb.Material_BLUE_LEAVES, Density: 0.03, MinHeight: 5, MaxHeight: 8, CanopyRadius: 2},
+	pb.Biome_GRASSLAND: {Trunk: pb.Material_YELLOW_WOOD, Leaves: pb.Material_BLUE_LEAVES, Density: 0.003, MinHeight: 4, MaxHeight: 6, CanopyRadius: 2},
+	pb.Biome_TUNDRA:    {Trunk: pb.Material_BLUE_WOOD, Leaves: pb.Material_BLUE_LEAVES, Density: 0.008, MinHeight: 6, MaxHeight: 9, CanopyRadius: 1},
+	pb.Biome_BADLANDS:  {Trunk: pb.Material_PURPLE_WOOD, Leaves: pb.Material_AIR, Density: 0.002, MinHeight: 2, MaxHeight: 4, CanopyRadius: 0},
+}
+
+// DistantColor returns the color of a biome seen from afar over ground of a material. Trees cover part of the ground,
+// so the color of their leaves is mixed in by how much of it their canopies hide.
+func DistantColor(biome pb.Biome, ground pb.Material) mgl32.Vec3 {
+	color := MaterialColors[ground]
+	tree, ok := BiomeTrees[biome]
+	if !ok || tree.Leaves == pb.Material_AIR {
+		return color
+	}
+	width := float64(2*tree.CanopyRadius + 1)
+	cover := math.Min(1, tree.Density*width*width)
+	return color.Mul(float32(1 - cover)).Add(MaterialColors[tree.Leaves].Mul(float32(cover)))
+}
+
+// maxTreeReach is the furthest any tree extends sideways from its root column, maxTreeHeight the most cells any tree
+// rises above the terrain it grows from, and maxTreeDensity the highest tree density of any biome
+var maxTreeReach, maxTreeHeight, maxTreeDensity = func() (int64, int64, float64) {
+	reach, height, density := 0, 0, 0.0
+	for _, tree := range BiomeTrees {
+		reach = Max(reach, tree.CanopyRadius)
+		height = Max(height, tree.MaxHeight+tree.CanopyRadius+1)
+		density = math.Max(density, tree.Density)
+	}
+	return int64(reach), int64(height), density
+}()
+
+// decorationRank orders the materials a decoration may overwrite.
+// A decoration only replaces cells of a lower rank, so overlapping decorations
+// come out the same no matter which is placed first. Terrain is never replaced.
+var decorationRank = map[pb.Material]int{
+	pb.Material_AIR:         0,
+	pb.Material_BLUE_LEAVES: 1,
+	pb.Material_BLUE_WOOD:   2,
+	pb.Material_GREEN_WOOD:  2,
+	pb.Material_PURPLE_WOOD: 2,
+	pb.Material_YELLOW_WOOD: 2,
+}
+
+// decorationCell is a single cell of a decoration
+type decorationCell struct {
+	Index    pb.CellIndex
+	Material pb.Material
+}
+
+var decorators map[string](func(*Planet, int64, int64) []decorationCell)
+
+func init() {
+	decorators = make(map[string](func(*Planet, int64, int64) []decorationCell))
+	decorators["biomes"] = func(p *Planet, lon, lat int64) []decorationCell {
+		return p.tree(lon, lat)
+	}
+}
+
+// hashCell returns a well mixed hash of a cell index for a planet seed and a salt
+func hashCell(seed, lon, lat, alt, salt int64) uint64 {
+	h := uint64(seed)*0x9e3779b97f4a7c15 ^ uint64(lon)*0xbf58476d1ce4e5b9 ^ uint64(lat)*0x94d049bb133111eb ^ uint64(alt)*0xd6e8feb86659fd93 ^ uint64(salt)
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// hashUnit returns a deterministic pseudo-random number in [0, 1) for a cell index
+func hashUnit(seed, lon, lat, alt, salt int64) float64 {
+	return float64(hashCell(seed, lon, lat, alt, salt)>>11) / (1 << 53)
+}
+
+// tree returns the cells of the tree rooted in a column, if any.
+// The trunk rises along increasing altitude, which is the local up direction on the planet.
+func (p *Planet) tree(lon, lat int64) []decorationCell {
+	seed := p.Spec.Seed
+	chance := hashUnit(seed, lon, lat, 0, 1)
+	if chance >= maxTreeDensity {
+		return nil
+	}
+	loc := pb.CellLoc{Lon: float64(lon), Lat: float64(lat), Alt: float64(p.Spec.AltCells - 1)}
+	species, ok := BiomeTrees[p.BiomeAt(loc)]
+	if !ok || chance >= species.Density {
+		return nil
+	}
+	height := p.SurfaceHeight(loc)
+	if height < p.SeaLevel() {
+		return nil
+	}
+	base := int64(math.Floor(height)) + 1
+	if base >= p.Spec.AltCells {
+		return nil
+	}
+
+	// Only root trees on cells that exist at the resolution of the chunk holding the base
+	lonCells, latCells := p.LonLatCellsInChunkIndex(p.CellIndexToChunkIndex(pb.CellIndex{Lon: lon, Lat: lat, Alt: base}))
+	if lon%(p.ChunkSize/int64(lonCells)) != 0 || lat%(p.ChunkSize/int64(latCells)) != 0 {
+		return nil
+	}
+
+	trunkHeight := int64(species.MinHeight) + int64(hashCell(seed, lon, lat, 0, 2)%uint64(species.MaxHeight-species.MinHeight+1))
+	cells := []decorationCell{}
+	for alt := base; alt < base+trunkHeight; alt++ {
+		cells = append(cells, decorationCell{Index: pb.CellIndex{Lon: lon, Lat: lat, Alt: alt}, Material: species.Trunk})
+	}
+	if species.Leaves == pb.Material_AIR {
+		return cells
+	}
+	r := int64(species.CanopyRadius)
+	top := base + trunkHeight - 1
+	for dLon := -r; dLon <= r; dLon++ {
+		for dLat := -r; dLat <= r; dLat++ {
+			for dAlt := -r; dAlt <= r+1; dAlt++ {
+				d2 := dLon*dLon + dLat*dLat + dAlt*dAlt
+				if d2 > r*r+1 {
+					continue
+				}
+				ind := pb.CellIndex{Lon: lon + dLon, Lat: lat + dLat, Alt: top + dAlt}
+
+				// Ragged edges, decided per cell so neighboring chunks agree
+				if d2 >= r*r && hashCell(seed, ind.Lon, ind.Lat, ind.Alt, 3)%3 == 0 {
+					continue
+				}
+				cells = append(cells, decorationCell{Index: ind, Material: species.Leaves})
+			}
+		}
+	}
+	return cells
+}
+
+// decorateChunk places decorations such as trees on the generated terrain of a chunk.
+// Every column whose decoration could reach into the chunk is visited, so a decoration
+// straddling chunk boundaries is placed identically into each chunk it touches.
+func (p *Planet) decorateChunk(ind pb.ChunkIndex, chunk *pb.Chunk) {
+	if p.Decorator == nil {
+		return
+	}
+	lonCells, latCells := p.LonLatCellsInChunkIndex(ind)
+	cs := p.ChunkSize
+	lonWidth := cs / int64(lonCells)
+	latWidth := cs / int64(latCells)
+
+	// Walk the roots from the corner of the chunk, which carries over the edges of cube-sphere faces
+	corner := pb.CellIndex{Lon: ind.Lon * cs, Lat: ind.Lat * cs}
+	for dLon := int64(-maxTreeReach); dLon < cs+maxTreeReach; dLon++ {
+		for dLat := int64(-maxTreeReach); dLat < cs+maxTreeReach; dLat++ {
+			root, ok := p.NeighborCellIndex(corner, dLon, dLat, 0)
+			if !ok {
+				continue
+			}
+			for _, d := range p.Decorator(p, root.Lon, root.Lat) {
+				// Place the cell by its offset from the root, so decorations near a face edge carry over it
+				cellInd, ok := p.NeighborCellIndex(pb.CellIndex{Lon: root.Lon, Lat: root.Lat, Alt: d.Index.Alt}, d.Index.Lon-root.Lon, d.Index.Lat-root.Lat, 0)
+				if !ok {
+					continue
+				}
+				cellChunk := p.CellIndexToChunkIndex(cellInd)
+				if cellChunk.Lon != ind.Lon || cellChunk.Lat != ind.Lat || cellChunk.Alt != ind.Alt {
+					continue
+				}
+				cell := chunk.Cell[(cellInd.Lon%cs)/lonWidth].Cell[(cellInd.Lat%cs)/latWidth].Cell[cellInd.Alt%cs]
+				rank, ok := decorationRank[cell.Material]
+				if !ok || rank >= decorationRank[d.Material] {
+					continue
+				}
+				cell.Material = d.Material
+			}
+		}
+	}
+}
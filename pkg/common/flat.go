@@ -0,0 +1,153 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// flatTopology lays cells out on a finite square of flat ground, centered over the planet's Z axis with up along Z.
+// Longitude runs along X and latitude runs along -Y, and neither wraps around.
+// It needs none of the spherical math, which makes it handy for custom worlds and tests.
+type flatTopology struct {
+	p *Planet
+}
+
+func newFlatTopology(p *Planet) Topology {
+	p.LonCells = int64(math.Max(float64(p.ChunkSize), float64(int64(p.Spec.Radius)/p.ChunkSize*p.ChunkSize)))
+	p.LatCells = p.LonCells
+	return &flatTopology{p: p}
+}
+
+// xOfLon converts a floating-point longitude cell index to the distance along X from the middle of the world
+func (f *flatTopology) xOfLon(lon float64) float64 {
+	return lon - float64(f.p.LonCells-1)/2
+}
+
+// yOfLat converts a floating-point latitude cell index to the distance along Y from the middle of the world
+func (f *flatTopology) yOfLat(lat float64) float64 {
+	return float64(f.p.LatCells-1)/2 - lat
+}
+
+// zOfAlt converts a floating-point altitude cell index to the height along Z
+func (f *flatTopology) zOfAlt(alt float64) float64 {
+	return alt*f.p.AltDelta + f.p.AltMin
+}
+
+func (f *flatTopology) CellLocToCartesian(l pb.CellLoc) mgl32.Vec3 {
+	return mgl32.Vec3{float32(f.xOfLon(l.Lon)), float32(f.yOfLat(l.Lat)), float32(f.zOfAlt(l.Alt))}
+}
+
+func (f *flatTopology) CellVertexToCartesian(ind pb.CellIndex, l pb.CellLoc) mgl32.Vec3 {
+	return f.CellLocToCartesian(l)
+}
+
+func (f *flatTopology) CartesianToCellLoc(cart mgl32.Vec3) pb.CellLoc {
+	p := f.p
+	return pb.CellLoc{
+		Lon: float64(cart[0]) + float64(p.LonCells-1)/2,
+		Lat: float64(p.LatCells-1)/2 - float64(cart[1]),
+		Alt: (float64(cart[2]) - p.AltMin) / p.AltDelta,
+	}
+}
+
+func (f *flatTopology) NearestCellCenter(l pb.CellLoc) pb.CellLoc {
+	return pb.CellLoc{
+		Lon: math.Floor(l.Lon + 0.5),
+		Lat: math.Floor(l.Lat + 0.5),
+		Alt: math.Floor(l.Alt + 0.5),
+	}
+}
+
+func (f *flatTopology) NeighborCellIndex(ind pb.CellIndex, dLon, dLat, dAlt int64) (pb.CellIndex, bool) {
+	return f.p.gridNeighborCellIndex(ind, dLon, dLat, dAlt, false)
+}
+
+func (f *flatTopology) LonLatCellsInChunkIndex(ind pb.ChunkIndex) (lonCells, latCells int) {
+	return int(f.p.ChunkSize), int(f.p.ChunkSize)
+}
+
+func (f *flatTopology) Up(cart mgl32.Vec3) mgl32.Vec3 {
+	return mgl32.Vec3{0, 0, 1}
+}
+
+// Latitude runs from one edge of the world to the other
+func (f *flatTopology) Latitude(l pb.CellLoc) float64 {
+	return f.p.gridLatitude(l)
+}
+
+// SpawnLoc is in the middle of the world, over the planet's Z axis
+func (f *flatTopology) SpawnLoc() pb.CellLoc {
+	p := f.p
+	return pb.CellLoc{Lon: float64(p.LonCells-1) / 2, Lat: float64(p.LatCells-1) / 2}
+}
+
+func (f *flatTopology) Closed() bool {
+	return false
+}
+
+func (f *flatTopology) geometrySampleLoc(lon, lat, lonSamples, latSamples int) pb.CellLoc {
+	return f.p.gridGeometrySampleLoc(lon, lat, lonSamples, latSamples)
+}
+
+// boxCell is the region of space covered by a cell of a flat world, a box lined up with the axes
+type boxCell struct {
+	min, max [3]float64
+}
+
+func (f *flatTopology) cellRegion(ind pb.CellIndex) cellRegion {
+	lonMin, lonMax, latMin, latMax, altMin, altMax := f.p.cellBounds(ind)
+	return boxCell{
+		min: [3]float64{f.xOfLon(lonMin), f.yOfLat(latMax), f.zOfAlt(altMin)},
+		max: [3]float64{f.xOfLon(lonMax), f.yOfLat(latMin), f.zOfAlt(altMax)},
+	}
+}
+
+func (c boxCell) push(center mgl32.Vec3, radius float64) (mgl32.Vec3, bool) {
+	pt := [3]float64{float64(center[0]), float64(center[1]), float64(center[2])}
+	inside := true
+	nearest := mgl32.Vec3{}
+	for i := range pt {
+		inside = inside && pt[i] >= c.min[i] && pt[i] <= c.max[i]
+		nearest[i] = float32(math.Max(c.min[i], math.Min(c.max[i], pt[i])))
+	}
+	if !inside {
+		return pushFromNearest(center, nearest, radius)
+	}
+
+	// The center is inside the cell, so leave through the nearest face
+	dir, dist := mgl32.Vec3{}, math.Inf(1)
+	for i := range pt {
+		axis := mgl32.Vec3{}
+		axis[i] = 1
+		if d := pt[i] - c.min[i]; d < dist {
+			dir, dist = axis.Mul(-1), d
+		}
+		if d := c.max[i] - pt[i]; d < dist {
+			dir, dist = axis, d
+		}
+	}
+	return dir.Mul(float32(dist + radius)), true
+}
+
+func (c boxCell) exit(o, v [3]float64, after float64) (float64, CellFace) {
+	e := newRayExit(after)
+	faces := [3][2]CellFace{{LonMinFace, LonMaxFace}, {LatMaxFace, LatMinFace}, {AltMinFace, AltMaxFace}}
+	for i := range o {
+		if v[i] == 0 {
+			continue
+		}
+		e.consider((c.min[i]-o[i])/v[i], faces[i][0])
+		e.consider((c.max[i]-o[i])/v[i], faces[i][1])
+	}
+	return e.t, e.face
+}
+
+func (f *flatTopology) stepAcross(ind pb.CellIndex, exit CellFace, pt mgl32.Vec3) (pb.CellIndex, CellFace, bool) {
+	return f.p.gridStepAcross(ind, exit)
+}
+
+func (f *flatTopology) solidCellsNear(center mgl32.Vec3, reach float64) []cellRegion {
+	return f.p.gridSolidCellsNear(center, reach, 1)
+}
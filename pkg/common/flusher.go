@@ -0,0 +1,62 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// minFlushInterval is the shortest time between background saves, which keeps a zero or tiny latency from busy saving
+const minFlushInterval = 10 * time.Millisecond
+
+// ChunkFlusher saves the changed chunks of the planets in a universe in the background, so edits never wait on the store
+type ChunkFlusher struct {
+	universe *Universe
+	stop     chan bool
+	done     chan bool
+	once     sync.Once
+}
+
+// NewChunkFlusher starts saving the changed chunks of a universe in batches.
+// A change is saved within maxLatency, as long as saving a batch takes less than half of that.
+// Latencies under twice minFlushInterval save as often as minFlushInterval allows.
+func NewChunkFlusher(u *Universe, maxLatency time.Duration) *ChunkFlusher {
+	f := ChunkFlusher{universe: u, stop: make(chan bool), done: make(chan bool)}
+	interval := maxLatency / 2
+	if interval < minFlushInterval {
+		interval = minFlushInterval
+	}
+	go f.run(interval)
+	return &f
+}
+
+func (f *ChunkFlusher) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.Flush()
+		case <-f.stop:
+			f.Flush()
+			close(f.done)
+			return
+		}
+	}
+}
+
+// Flush saves every changed chunk now, returning how many it saved
+func (f *ChunkFlusher) Flush() int {
+	saved := 0
+	for _, planet := range f.universe.PlanetMap {
+		saved += planet.FlushChunks()
+	}
+	return saved
+}
+
+// Close stops the flusher, returning once every changed chunk is saved
+func (f *ChunkFlusher) Close() {
+	f.once.Do(func() {
+		close(f.stop)
+	})
+	<-f.done
+}
@@ -0,0 +1,30 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+func TestChunkFlusherShortLatency(t *testing.T) {
+	for _, latency := range []time.Duration{0, 1, -time.Second, 50 * time.Millisecond} {
+		u := testUniverse("flat", 1)
+		planet := u.PlanetMap[0]
+		f := NewChunkFlusher(u, latency)
+		ind := pb.CellIndex{Lon: 10, Lat: 10, Alt: planet.Spec.AltCells - 1}
+		if !planet.SetCellMaterial(ind, pb.Material_STONE, false) {
+			t.Fatalf("latency %v: setting a cell changed nothing", latency)
+		}
+
+		// The change is saved in the background without waiting for Close
+		deadline := time.Now().Add(2 * time.Second)
+		for planet.DirtyChunks() > 0 && time.Now().Before(deadline) {
+			time.Sleep(minFlushInterval)
+		}
+		if dirty := planet.DirtyChunks(); dirty > 0 {
+			t.Errorf("latency %v: %v chunks left unsaved", latency, dirty)
+		}
+		f.Close()
+	}
+}
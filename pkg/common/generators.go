@@ -2,9 +2,21 @@ package common
 
 import pb "github.com/jeffbaumes/govox/pkg/govox"
 
+// terrainRelief is the furthest the terrain of the bumpy and biomes generators strays from halfway up a planet's cells
+const terrainRelief = 8
+
+// terrainBounds bounds the altitudes over which the terrain of a generator varies.
+// Every cell below Solid is Fill before ores are placed, and every cell at or above Air is air, decorations included.
+type terrainBounds struct {
+	Solid float64
+	Air   float64
+	Fill  pb.Material
+}
+
 var (
-	generators map[string](func(*Planet, pb.CellLoc) pb.Cell)
-	systems    map[string](func() []*pb.PlanetSpec)
+	generators      map[string](func(*Planet, pb.CellLoc) pb.Cell)
+	generatorBounds map[string](func(*Planet) terrainBounds)
+	systems         map[string](func(seed int64) []*pb.PlanetSpec)
 )
 
 func init() {
@@ -46,9 +58,9 @@ func init() {
 	}
 
 	generators["bumpy"] = func(p *Planet, loc pb.CellLoc) pb.Cell {
-		pos := p.CellLocToCartesian(loc).Normalize().Mul(float32(p.Spec.AltCells / 2))
+		pos := p.surfacePoint(loc, float64(p.Spec.AltCells/2))
 		scale := 0.1
-		height := float64(p.Spec.AltCells)/2 + p.noise.Eval3(float64(pos[0])*scale, float64(pos[1])*scale, float64(pos[2])*scale)*8
+		height := float64(p.Spec.AltCells)/2 + p.noise.Eval3(float64(pos[0])*scale, float64(pos[1])*scale, float64(pos[2])*scale)*terrainRelief
 		if float64(loc.Alt) <= height {
 			if float64(loc.Alt) > float64(p.Spec.AltCells)/2+2 {
 				return pb.Cell{Material: pb.Material_DIRT}
@@ -61,6 +73,33 @@ func init() {
 		return pb.Cell{Material: pb.Material_AIR}
 	}
 
+	generators["biomes"] = func(p *Planet, loc pb.CellLoc) pb.Cell {
+		height := p.SurfaceHeight(loc)
+		depth := height - float64(loc.Alt)
+		if depth >= float64(maxBiomeDepth) {
+			return pb.Cell{Material: pb.Material_STONE}
+		}
+		if depth < 0 {
+			sea := p.SeaLevel()
+			if float64(loc.Alt) >= sea {
+				return pb.Cell{Material: pb.Material_AIR}
+			}
+			// Freeze the top of the sea near the poles
+			if float64(loc.Alt) >= sea-1 && p.climateBiome(loc, height) == pb.Biome_POLAR {
+				return pb.Cell{Material: pb.Material_BLUE_SAND}
+			}
+			return pb.Cell{Material: pb.Material_BLUE_BLOCK}
+		}
+		layers := BiomeLayers[p.climateBiome(loc, height)]
+		if depth < 1 {
+			return pb.Cell{Material: layers.Surface}
+		}
+		if depth < float64(1+layers.SubsurfaceDepth) {
+			return pb.Cell{Material: layers.Subsurface}
+		}
+		return pb.Cell{Material: pb.Material_STONE}
+	}
+
 	generators["caves"] = func(p *Planet, loc pb.CellLoc) pb.Cell {
 		pos := p.CellLocToCartesian(loc)
 		const scale = 0.05
@@ -81,35 +120,131 @@ func init() {
 		return pb.Cell{Material: pb.Material_AIR}
 	}
 
-	systems = make(map[string](func() []*pb.PlanetSpec))
+	// Generators whose terrain stays within known altitudes can skip generating the cells of chunks wholly above or below it.
+	// Noise may stray a little past its nominal range, so the bounds leave a cell to spare.
+	generatorBounds = make(map[string](func(*Planet) terrainBounds))
 
-	systems["planet"] = func() []*pb.PlanetSpec {
+	halfway := func(p *Planet) float64 {
+		return float64(p.Spec.AltCells) / 2
+	}
+	generatorBounds["sphere"] = func(p *Planet) terrainBounds {
+		return terrainBounds{Solid: halfway(p), Air: halfway(p), Fill: pb.Material_STONE}
+	}
+	generatorBounds["moon"] = func(p *Planet) terrainBounds {
+		return terrainBounds{Solid: halfway(p), Air: halfway(p), Fill: pb.Material_MOON}
+	}
+	generatorBounds["sun"] = func(p *Planet) terrainBounds {
+		return terrainBounds{Solid: halfway(p), Air: halfway(p), Fill: pb.Material_SUN}
+	}
+	generatorBounds["bumpy"] = func(p *Planet) terrainBounds {
+		return terrainBounds{Solid: halfway(p) - terrainRelief - 1, Air: halfway(p) + terrainRelief + 2, Fill: pb.Material_GRASS}
+	}
+	generatorBounds["biomes"] = func(p *Planet) terrainBounds {
+		return terrainBounds{
+			Solid: halfway(p) - terrainRelief - 1 - float64(maxBiomeDepth),
+			Air:   halfway(p) + terrainRelief + 2 + float64(maxTreeHeight),
+			Fill:  pb.Material_STONE,
+		}
+	}
+
+	systems = make(map[string](func(seed int64) []*pb.PlanetSpec))
+
+	systems["planet"] = func(seed int64) []*pb.PlanetSpec {
 		return []*pb.PlanetSpec{
 			&pb.PlanetSpec{
-				Id:              0,
-				Name:            "Spawn",
-				GeneratorType:   "bumpy",
-				Radius:          64.0,
-				AltCells:        64,
-				RotationSeconds: 10,
+				Id:                0,
+				Name:              "Spawn",
+				GeneratorType:     "bumpy",
+				SurfaceGravity:    20,
+				AtmosphereDensity: 1,
+				Radius:            64.0,
+				AltCells:          64,
+				RotationSeconds:   10,
 			},
 		}
 	}
 
-	systems["moon"] = func() []*pb.PlanetSpec {
+	// Only the biomes system has a spawn planet of biomes, so that worlds of the other systems keep their terrain
+	systems["biomes"] = func(seed int64) []*pb.PlanetSpec {
 		return []*pb.PlanetSpec{
 			&pb.PlanetSpec{
-				Id:              0,
-				Name:            "Spawn",
-				GeneratorType:   "bumpy",
-				Radius:          64.0,
-				AltCells:        64,
-				RotationSeconds: 10,
+				Id:                0,
+				Name:              "Spawn",
+				GeneratorType:     "biomes",
+				SurfaceGravity:    20,
+				AtmosphereDensity: 1,
+				Radius:            64.0,
+				AltCells:          64,
+				RotationSeconds:   10,
+			},
+		}
+	}
+
+	systems["cube"] = func(seed int64) []*pb.PlanetSpec {
+		return []*pb.PlanetSpec{
+			&pb.PlanetSpec{
+				Id:                0,
+				Name:              "Spawn",
+				GeneratorType:     "bumpy",
+				Topology:          CubeTopology,
+				SurfaceGravity:    20,
+				AtmosphereDensity: 1,
+				Radius:            64.0,
+				AltCells:          64,
+				RotationSeconds:   10,
+			},
+		}
+	}
+
+	systems["ring"] = func(seed int64) []*pb.PlanetSpec {
+		return []*pb.PlanetSpec{
+			&pb.PlanetSpec{
+				Id:                0,
+				Name:              "Spawn",
+				GeneratorType:     "bumpy",
+				Topology:          RingTopology,
+				SurfaceGravity:    20,
+				AtmosphereDensity: 1,
+				Radius:            128.0,
+				AltCells:          64,
+				RotationSeconds:   10,
+			},
+		}
+	}
+
+	systems["flat"] = func(seed int64) []*pb.PlanetSpec {
+		return []*pb.PlanetSpec{
+			&pb.PlanetSpec{
+				Id:                0,
+				Name:              "Spawn",
+				GeneratorType:     "bumpy",
+				Topology:          FlatTopology,
+				SurfaceGravity:    20,
+				AtmosphereDensity: 1,
+				Radius:            128.0,
+				AltCells:          64,
+				RotationSeconds:   10,
+			},
+		}
+	}
+
+	systems["moon"] = func(seed int64) []*pb.PlanetSpec {
+		return []*pb.PlanetSpec{
+			&pb.PlanetSpec{
+				Id:                0,
+				Name:              "Spawn",
+				GeneratorType:     "bumpy",
+				SurfaceGravity:    20,
+				AtmosphereDensity: 1,
+				Radius:            64.0,
+				AltCells:          64,
+				RotationSeconds:   10,
 			},
 			&pb.PlanetSpec{
 				Id:              1,
 				Name:            "Moon",
 				GeneratorType:   "moon",
+				SurfaceGravity:  6,
 				Radius:          32.0,
 				AltCells:        32,
 				OrbitPlanet:     0,
@@ -120,23 +255,27 @@ func init() {
 		}
 	}
 
-	systems["sun-moon"] = func() []*pb.PlanetSpec {
+	systems["sun-moon"] = func(seed int64) []*pb.PlanetSpec {
 		return []*pb.PlanetSpec{
 			&pb.PlanetSpec{
-				Id:              0,
-				Name:            "Spawn",
-				GeneratorType:   "bumpy",
-				Radius:          64.0,
-				AltCells:        64,
-				OrbitPlanet:     2,
-				OrbitDistance:   300,
-				OrbitSeconds:    1095,
-				RotationSeconds: 180,
+				Id:                0,
+				Name:              "Spawn",
+				GeneratorType:     "bumpy",
+				SurfaceGravity:    20,
+				AtmosphereDensity: 1,
+				Radius:            64.0,
+				AltCells:          64,
+				OrbitPlanet:       2,
+				OrbitDistance:     300,
+				OrbitSeconds:      1095,
+				RotationSeconds:   180,
+				AxialTilt:         23.5,
 			},
 			&pb.PlanetSpec{
 				Id:              1,
 				Name:            "Moon",
 				GeneratorType:   "moon",
+				SurfaceGravity:  6,
 				Radius:          32.0,
 				AltCells:        32,
 				OrbitPlanet:     0,
@@ -148,6 +287,7 @@ func init() {
 				Id:              2,
 				Name:            "Sun",
 				GeneratorType:   "sun",
+				SurfaceGravity:  40,
 				Radius:          64.0,
 				AltCells:        64,
 				OrbitPlanet:     2,
@@ -156,12 +296,13 @@ func init() {
 		}
 	}
 
-	systems["many"] = func() []*pb.PlanetSpec {
+	systems["many"] = func(seed int64) []*pb.PlanetSpec {
 		planets := []*pb.PlanetSpec{
 			&pb.PlanetSpec{
 				Id:              0,
 				Name:            "Sun",
 				GeneratorType:   "sun",
+				SurfaceGravity:  40,
 				Radius:          64.0,
 				AltCells:        64,
 				OrbitPlanet:     0,
@@ -195,4 +336,5 @@ func init() {
 		return planets
 	}
 
+	systems["procedural"] = proceduralSystem
 }
@@ -0,0 +1,134 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// EccentricAnomaly solves Kepler's equation M = E - e sin(E) for the eccentric anomaly E
+// given the mean anomaly M and eccentricity e, both in radians
+func EccentricAnomaly(meanAnomaly, eccentricity float64) float64 {
+	m := math.Mod(meanAnomaly, 2*math.Pi)
+	e := m
+	if eccentricity > 0.8 {
+		e = math.Pi
+	}
+	for i := 0; i < 50; i++ {
+		delta := (e - eccentricity*math.Sin(e) - m) / (1 - eccentricity*math.Cos(e))
+		e -= delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+	return e
+}
+
+// OrbitOffset returns the position of a planet relative to the planet it orbits at a time in seconds.
+// OrbitDistance is the semi-major axis of the orbit. Inclination, AscendingNode and OrbitPhase are in degrees,
+// with OrbitPhase the mean anomaly at time zero.
+func OrbitOffset(spec *pb.PlanetSpec, time float64) mgl32.Vec3 {
+	meanAnomaly := spec.OrbitPhase * math.Pi / 180
+	if spec.OrbitSeconds != 0 {
+		_, timeOfOrbit := math.Modf(time / spec.OrbitSeconds)
+		meanAnomaly += 2 * math.Pi * timeOfOrbit
+	}
+	ecc := math.Min(math.Max(spec.Eccentricity, 0), 0.99)
+	anomaly := EccentricAnomaly(meanAnomaly, ecc)
+
+	// Position in the orbital plane, with periapsis along x
+	a := spec.OrbitDistance
+	x := a * (math.Cos(anomaly) - ecc)
+	y := a * math.Sqrt(1-ecc*ecc) * math.Sin(anomaly)
+
+	// Tilt the orbital plane about the line of nodes, then turn the line of nodes into place
+	inc := spec.Inclination * math.Pi / 180
+	node := spec.AscendingNode * math.Pi / 180
+	y, z := y*math.Cos(inc), y*math.Sin(inc)
+	x, y = x*math.Cos(node)-y*math.Sin(node), x*math.Sin(node)+y*math.Cos(node)
+	return mgl32.Vec3{float32(x), float32(y), float32(z)}
+}
+
+// SystemLocation returns the position of a planet at a time in seconds in the frame of the planet
+// at the root of its chain of orbits. A planet that orbits itself is at the origin.
+func SystemLocation(id int64, time float64, spec func(int64) *pb.PlanetSpec) mgl32.Vec3 {
+	s := spec(id)
+	if s == nil || s.Id == s.OrbitPlanet {
+		return mgl32.Vec3{}
+	}
+	return SystemLocation(s.OrbitPlanet, time, spec).Add(OrbitOffset(s, time))
+}
+
+// PlanetLocation returns the position of a planet in the universe at a time in seconds
+func (u *Universe) PlanetLocation(id int64, time float64) mgl32.Vec3 {
+	return SystemLocation(id, time, func(id int64) *pb.PlanetSpec {
+		planet := u.PlanetMap[id]
+		if planet == nil {
+			return nil
+		}
+		return &planet.Spec
+	})
+}
+
+// PlanetRotation returns the rotation taking planet coordinates to universe coordinates at a time in seconds.
+// The planet spins about its own Z axis, which is tilted AxialTilt degrees from the universe Z axis
+// towards the universe XY direction TiltDirection degrees from X.
+func PlanetRotation(spec *pb.PlanetSpec, time float64) mgl32.Mat3 {
+	_, timeOfDay := math.Modf(time / spec.RotationSeconds)
+	spin := mgl32.Rotate3DZ(-float32(2 * math.Pi * timeOfDay))
+	tilt := mgl32.Rotate3DZ(float32(spec.TiltDirection * math.Pi / 180)).Mul3(mgl32.Rotate3DY(float32(spec.AxialTilt * math.Pi / 180)))
+	return tilt.Mul3(spin)
+}
+
+// StarID returns the ID of the sun that lights a system, the planet with the sun generator and the lowest ID,
+// and false if there is none
+func StarID(specs []*pb.PlanetSpec) (int64, bool) {
+	var star *pb.PlanetSpec
+	for _, spec := range specs {
+		if spec.GeneratorType == "sun" && (star == nil || spec.Id < star.Id) {
+			star = spec
+		}
+	}
+	if star == nil {
+		return 0, false
+	}
+	return star.Id, true
+}
+
+// Star returns the sun that lights a universe, or nil if there is none
+func (u *Universe) Star() *Planet {
+	specs := []*pb.PlanetSpec{}
+	for _, planet := range u.PlanetMap {
+		specs = append(specs, &planet.Spec)
+	}
+	if id, ok := StarID(specs); ok {
+		return u.PlanetMap[id]
+	}
+	return nil
+}
+
+// SunElevation returns the angle in degrees of the sun above the horizon at a location on the planet,
+// given the positions of the planet and the sun in the universe
+func (p *Planet) SunElevation(l pb.CellLoc, planetLoc, sunLoc mgl32.Vec3, time float64) float64 {
+	rotation := PlanetRotation(&p.Spec, time)
+	cart := p.CellLocToCartesian(l)
+	pos := rotation.Mul3x1(cart)
+	up := rotation.Mul3x1(p.Up(cart))
+	toSun := sunLoc.Sub(planetLoc.Add(pos)).Normalize()
+	return math.Asin(math.Max(-1, math.Min(1, float64(up.Dot(toSun))))) * 180 / math.Pi
+}
+
+// SunElevation returns the angle in degrees of the sun above the horizon at a location on a planet at a time in seconds.
+// The sun lights itself, so it is overhead everywhere on the sun. It returns false if the planet is unknown or there is no sun.
+func (u *Universe) SunElevation(planetID int64, l pb.CellLoc, time float64) (float64, bool) {
+	planet := u.PlanetMap[planetID]
+	star := u.Star()
+	if planet == nil || star == nil {
+		return 0, false
+	}
+	if planet == star {
+		return 90, true
+	}
+	return planet.SunElevation(l, u.PlanetLocation(planetID, time), u.PlanetLocation(star.Spec.Id, time), time), true
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// testUniverse builds a client universe of planets with in-memory stores from one of the registered systems
+func testUniverse(system string, seed int64) *Universe {
+	specs := systems[system](seed)
+	planets := []*Planet{}
+	for _, spec := range specs {
+		planets = append(planets, NewPlanet(nil, NewMemoryChunkStore(), *spec))
+	}
+	return NewClientUniverse(planets)
+}
+
+func TestSunElevation(t *testing.T) {
+	tests := []struct {
+		system string
+		star   int64
+		hasSun bool
+	}{
+		{"planet", 0, false},
+		{"moon", 0, false},
+		{"sun-moon", 2, true},
+		{"procedural", 1, true},
+	}
+	for _, test := range tests {
+		u := testUniverse(test.system, 1)
+		star := u.Star()
+		if (star != nil) != test.hasSun {
+			t.Errorf("%v: found star %v, want a star %v", test.system, star != nil, test.hasSun)
+			continue
+		}
+		if !test.hasSun {
+			if _, ok := u.SunElevation(0, pb.CellLoc{}, 0); ok {
+				t.Errorf("%v: got a sun elevation with no star", test.system)
+			}
+			continue
+		}
+		if star.Spec.Id != test.star {
+			t.Errorf("%v: star is planet %v, want %v", test.system, star.Spec.Id, test.star)
+		}
+		if e, ok := u.SunElevation(test.star, pb.CellLoc{Lat: 1, Lon: 1, Alt: 1}, 0); !ok || e != 90 {
+			t.Errorf("%v: elevation on the star is %v, %v, want 90, true", test.system, e, ok)
+		}
+		if _, ok := u.SunElevation(-1, pb.CellLoc{}, 0); ok {
+			t.Errorf("%v: got a sun elevation on an unknown planet", test.system)
+		}
+
+		// The sun is overhead where the spawn planet faces it and underfoot on the far side, with day and night between
+		spawn := u.PlanetMap[0]
+		day, night := false, false
+		for _, time := range []float64{0, 37, 1000} {
+			toSun := u.PlanetLocation(test.star, time).Sub(u.PlanetLocation(0, time))
+			facing := PlanetRotation(&spawn.Spec, time).Transpose().Mul3x1(toSun).Normalize().Mul(float32(spawn.Spec.Radius))
+			if e, _ := u.SunElevation(0, spawn.CartesianToCellLoc(facing), time); e < 89 {
+				t.Errorf("%v: elevation facing the sun at %v is %v, want 90", test.system, time, e)
+			}
+			if e, _ := u.SunElevation(0, spawn.CartesianToCellLoc(facing.Mul(-1)), time); e > -89 {
+				t.Errorf("%v: elevation facing away from the sun at %v is %v, want -90", test.system, time, e)
+			}
+			for i := 0; i < 64; i++ {
+				a := 2 * math.Pi * float64(i) / 64
+				for _, z := range []float32{-0.5, 0, 0.5} {
+					cart := mgl32.Vec3{float32(math.Cos(a)), float32(math.Sin(a)), z}.Normalize().Mul(float32(spawn.Spec.Radius))
+					e, ok := u.SunElevation(0, spawn.CartesianToCellLoc(cart), time)
+					if !ok {
+						t.Fatalf("%v: no sun elevation on the spawn planet", test.system)
+					}
+					day = day || e > 0
+					night = night || e < 0
+				}
+			}
+		}
+		if !day || !night {
+			t.Errorf("%v: spawn planet has day %v and night %v, want both", test.system, day, night)
+		}
+	}
+}
+
+func TestStarID(t *testing.T) {
+	tests := []struct {
+		specs []*pb.PlanetSpec
+		want  int64
+		ok    bool
+	}{
+		{nil, 0, false},
+		{[]*pb.PlanetSpec{{Id: 0, GeneratorType: "sphere"}, {Id: 1, GeneratorType: "moon"}}, 0, false},
+		{[]*pb.PlanetSpec{{Id: 0, GeneratorType: "sphere"}, {Id: 2, GeneratorType: "sun"}}, 2, true},
+		{[]*pb.PlanetSpec{{Id: 5, GeneratorType: "sun"}, {Id: 1, GeneratorType: "sphere"}, {Id: 3, GeneratorType: "sun"}}, 3, true},
+	}
+	for i, test := range tests {
+		if id, ok := StarID(test.specs); id != test.want || ok != test.ok {
+			t.Errorf("system %v: star is %v, %v, want %v, %v", i, id, ok, test.want, test.ok)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package common
+
+import (
+	"math"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// OreShape is the form an ore deposit takes
+type OreShape int
+
+// Ore shapes
+const (
+	// OreBody is a rounded blob of ore
+	OreBody OreShape = iota
+	// OreVein is a thin winding tube of ore
+	OreVein
+)
+
+// OreDistribution describes where an ore is found inside a planet.
+// Depths are fractions of the way from the nominal surface (0) to the core (1).
+// Ore is most common at PeakDepth and fades out linearly towards MinDepth and MaxDepth.
+type OreDistribution struct {
+	Ore       pb.Material
+	Host      pb.Material
+	Shape     OreShape
+	MinDepth  float64
+	PeakDepth float64
+	MaxDepth  float64
+	Scale     float64
+	Richness  float64
+}
+
+var (
+	stoneOres = []OreDistribution{
+		{Ore: pb.Material_COAL_ORE, Host: pb.Material_STONE, Shape: OreBody, MinDepth: 0, PeakDepth: 0.15, MaxDepth: 0.45, Scale: 0.15, Richness: 0.5},
+		{Ore: pb.Material_IRON_ORE, Host: pb.Material_STONE, Shape: OreVein, MinDepth: 0.1, PeakDepth: 0.4, MaxDepth: 0.75, Scale: 0.08, Richness: 0.6},
+		{Ore: pb.Material_GOLD_ORE, Host: pb.Material_STONE, Shape: OreVein, MinDepth: 0.5, PeakDepth: 0.8, MaxDepth: 1, Scale: 0.1, Richness: 0.4},
+		{Ore: pb.Material_CRYSTAL_ORE, Host: pb.Material_STONE, Shape: OreBody, MinDepth: 0.6, PeakDepth: 0.85, MaxDepth: 1, Scale: 0.2, Richness: 0.5},
+	}
+	rockOres = []OreDistribution{
+		{Ore: pb.Material_COAL_ORE, Host: pb.Material_STONE, Shape: OreBody, MinDepth: -1, PeakDepth: 0, MaxDepth: 1, Scale: 0.15, Richness: 0.4},
+		{Ore: pb.Material_IRON_ORE, Host: pb.Material_STONE, Shape: OreVein, MinDepth: -1, PeakDepth: 0, MaxDepth: 1, Scale: 0.08, Richness: 0.5},
+	}
+	moonOres = []OreDistribution{
+		{Ore: pb.Material_IRON_ORE, Host: pb.Material_MOON, Shape: OreBody, MinDepth: 0, PeakDepth: 0.2, MaxDepth: 0.6, Scale: 0.15, Richness: 0.45},
+		{Ore: pb.Material_CRYSTAL_ORE, Host: pb.Material_MOON, Shape: OreVein, MinDepth: 0.3, PeakDepth: 0.6, MaxDepth: 1, Scale: 0.1, Richness: 0.6},
+	}
+)
+
+// oreDistributions maps each generator type to the ores found in its planets
+var oreDistributions = map[string][]OreDistribution{
+	"sphere": stoneOres,
+	"biomes": stoneOres,
+	"caves":  stoneOres,
+	"rocks":  rockOres,
+	"moon":   moonOres,
+}
+
+// weight returns how common the ore is at a depth, from 0 to 1
+func (d OreDistribution) weight(depth float64) float64 {
+	if depth < d.MinDepth || depth > d.MaxDepth {
+		return 0
+	}
+	if depth < d.PeakDepth {
+		return (depth - d.MinDepth) / (d.PeakDepth - d.MinDepth)
+	}
+	if depth > d.PeakDepth {
+		return (d.MaxDepth - depth) / (d.MaxDepth - d.PeakDepth)
+	}
+	return 1
+}
+
+// ore returns the material of a generated cell after placing ores in it.
+// Ores depend only on the planet seed and the cell location, so chunks agree no matter the order they are generated in.
+func (p *Planet) ore(l pb.CellLoc, host pb.Material) pb.Material {
+	if len(p.Ores) == 0 {
+		return host
+	}
+	depth := 1 - l.Alt/(float64(p.Spec.AltCells)/2)
+	pos := p.CellLocToCartesian(l)
+	for _, d := range p.Ores {
+		if d.Host != host {
+			continue
+		}
+		w := d.weight(depth) * d.Richness
+		if w <= 0 {
+			continue
+		}
+
+		// Offset the noise for each ore so that different ores do not overlap
+		offset := 100 * float64(d.Ore)
+		x, y, z := float64(pos[0])*d.Scale+offset, float64(pos[1])*d.Scale, float64(pos[2])*d.Scale
+		switch d.Shape {
+		case OreBody:
+			if p.noise.Eval3(x, y, z) > 1-w {
+				return d.Ore
+			}
+		case OreVein:
+			// Veins follow where two independent noise fields both cross zero
+			width := 0.12 * w
+			if math.Abs(p.noise.Eval3(x, y, z)) < width && math.Abs(p.noise.Eval3(x, y+offset, z)) < width {
+				return d.Ore
+			}
+		}
+	}
+	return host
+}
@@ -1,65 +1,122 @@
 package common
 
 import (
-	"bytes"
 	"context"
-	"database/sql"
-	"encoding/gob"
+	"fmt"
 	"log"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/go-gl/mathgl/mgl32"
+	"github.com/golang/protobuf/proto"
 	pb "github.com/jeffbaumes/govox/pkg/govox"
 	opensimplex "github.com/ojrac/opensimplex-go"
 )
 
-// ChunkSize is the number of cells per side of a chunk
+// Defaults for planets whose spec leaves out the size of their chunks and cells
 const (
-	ChunkSize = 16
+	// DefaultChunkSize is the number of cells per side of a chunk
+	DefaultChunkSize = 16
+
+	// DefaultCellHeight is the height of a cell in world units
+	DefaultCellHeight = 1.0
 )
 
-// Planet represents all the cells in a spherical planet
+// DefaultMaxChunks is the number of chunks a planet keeps in memory before it starts evicting the least recently used
+const DefaultMaxChunks = 4096
+
+// Planet represents all the cells in a planet, laid out in space by its topology
 type Planet struct {
-	grpcClient    pb.GovoxClient
-	db            *sql.DB
-	Geometry      *pb.PlanetGeometry
-	GeometryMutex *sync.Mutex
-	Chunks        map[ChunkKey]*pb.Chunk
-	databaseMutex *sync.Mutex
-	ChunksMutex   *sync.Mutex
-	noise         *opensimplex.Noise
-	Generator     func(*Planet, pb.CellLoc) pb.Cell
-	AltMin        float64
-	AltDelta      float64
-	LatMax        float64
-	LonCells      int64
-	LatCells      int64
-	Spec          pb.PlanetSpec
-}
-
-// NewPlanet constructs a Planet instance
-func NewPlanet(grpcClient pb.GovoxClient, db *sql.DB, spec pb.PlanetSpec) *Planet {
+	grpcClient     pb.GovoxClient
+	store          ChunkStore
+	Geometry       *pb.PlanetGeometry
+	GeometryMutex  *sync.Mutex
+	Chunks         map[ChunkKey]*pb.Chunk
+	ChunksMutex    *sync.Mutex
+	MaxChunks      int
+	chunkUses      map[ChunkKey]int64
+	useCount       int64
+	evictions      int64
+	dirtyChunks    map[ChunkKey]bool
+	dirtyMutex     *sync.Mutex
+	flushMutex     *sync.Mutex
+	noise          *opensimplex.Noise
+	Generator      func(*Planet, pb.CellLoc) pb.Cell
+	bounds         func(*Planet) terrainBounds
+	BiomeGenerator func(*Planet, pb.CellLoc) pb.Biome
+	Decorator      func(*Planet, int64, int64) []decorationCell
+	Ores           []OreDistribution
+	Topology       Topology
+	ChunkSize      int64
+	AltMin         float64
+	AltDelta       float64
+	LatMax         float64
+	LonCells       int64
+	LatCells       int64
+	Spec           pb.PlanetSpec
+}
+
+// ValidatePlanetSpec returns an error if a planet spec does not describe a planet,
+// so that a bad spec is refused where it enters a world instead of becoming a planet
+func ValidatePlanetSpec(spec *pb.PlanetSpec) error {
+	// Chunks near the poles and the core split their cells into halves, so their size must be a power of two
+	if spec.ChunkSize < 0 || spec.ChunkSize&(spec.ChunkSize-1) != 0 {
+		return fmt.Errorf("chunk size %v of planet %v is not a power of two", spec.ChunkSize, spec.Id)
+	}
+	return nil
+}
+
+// NewPlanet constructs a Planet instance.
+// Chunks come from the server when there is a client, or else from the store, which may be nil to keep no chunks.
+func NewPlanet(grpcClient pb.GovoxClient, store ChunkStore, spec pb.PlanetSpec) *Planet {
 	p := Planet{}
 	p.Spec = spec
 	p.grpcClient = grpcClient
 	p.noise = opensimplex.NewWithSeed(int64(p.Spec.Seed))
-	p.Spec.AltCells = p.Spec.AltCells / ChunkSize * ChunkSize
-	p.AltMin = p.Spec.Radius - float64(p.Spec.AltCells)
-	p.AltDelta = 1.0
+	if p.Spec.ChunkSize <= 0 {
+		p.Spec.ChunkSize = DefaultChunkSize
+	}
+	// Chunks near the poles and the core split their cells into halves, so keep their size a power of two.
+	// Specs are validated where they enter a world, so this only keeps a planet usable whatever its spec.
+	for p.Spec.ChunkSize&(p.Spec.ChunkSize-1) != 0 {
+		p.Spec.ChunkSize &= p.Spec.ChunkSize - 1
+	}
+	if p.Spec.CellHeight <= 0 {
+		p.Spec.CellHeight = DefaultCellHeight
+	}
+	p.ChunkSize = p.Spec.ChunkSize
+	p.AltDelta = p.Spec.CellHeight
+	if float64(p.Spec.AltCells)*p.AltDelta > p.Spec.Radius {
+		p.Spec.AltCells = int64(p.Spec.Radius / p.AltDelta)
+	}
+	p.Spec.AltCells = p.Spec.AltCells / p.ChunkSize * p.ChunkSize
+	p.AltMin = p.Spec.Radius - float64(p.Spec.AltCells)*p.AltDelta
 	p.LatMax = 90.0
-	p.LonCells = int64(2.0*math.Pi*3.0/4.0*(0.5*p.Spec.Radius)+0.5) / ChunkSize * ChunkSize
-	p.LatCells = int64(p.LatMax/90.0*math.Pi*(0.5*p.Spec.Radius)) / ChunkSize * ChunkSize
+	newTopology := topologies[p.Spec.Topology]
+	if newTopology == nil {
+		newTopology = topologies[SphereTopology]
+	}
+	p.Topology = newTopology(&p)
 	p.Chunks = make(map[ChunkKey]*pb.Chunk)
-	p.db = db
-	p.databaseMutex = &sync.Mutex{}
+	p.store = store
 	p.ChunksMutex = &sync.Mutex{}
+	p.MaxChunks = DefaultMaxChunks
+	p.chunkUses = make(map[ChunkKey]int64)
+	p.dirtyChunks = make(map[ChunkKey]bool)
+	p.dirtyMutex = &sync.Mutex{}
+	p.flushMutex = &sync.Mutex{}
 	p.GeometryMutex = &sync.Mutex{}
 	p.Generator = generators[p.Spec.GeneratorType]
+	p.bounds = generatorBounds[p.Spec.GeneratorType]
 	if p.Generator == nil {
 		p.Generator = generators["sphere"]
+		p.bounds = generatorBounds["sphere"]
 	}
+	p.BiomeGenerator = biomeGenerators[p.Spec.GeneratorType]
+	p.Decorator = decorators[p.Spec.GeneratorType]
+	p.Ores = oreDistributions[p.Spec.GeneratorType]
 	return &p
 }
 
@@ -68,21 +125,29 @@ type ChunkKey struct {
 	Lon, Lat, Alt int64
 }
 
+// CellKey stores the latitude, longitude, and altitude index of a cell
+type CellKey struct {
+	Lon, Lat, Alt int64
+}
+
 // GetChunk retrieves the chunk of a planet from chunk indices, either synchronously or asynchronously
 func (p *Planet) GetChunk(ind pb.ChunkIndex, async bool) *pb.Chunk {
-	if ind.Lon < 0 || ind.Lon >= p.LonCells/ChunkSize {
+	if ind.Lon < 0 || ind.Lon >= p.LonCells/p.ChunkSize {
 		return nil
 	}
-	if ind.Lat < 0 || ind.Lat >= p.LatCells/ChunkSize {
+	if ind.Lat < 0 || ind.Lat >= p.LatCells/p.ChunkSize {
 		return nil
 	}
-	if ind.Alt < 0 || ind.Alt >= p.Spec.AltCells/ChunkSize {
+	if ind.Alt < 0 || ind.Alt >= p.Spec.AltCells/p.ChunkSize {
 		return nil
 	}
 
 	p.ChunksMutex.Lock()
 	key := ChunkKey{Lon: ind.Lon, Lat: ind.Lat, Alt: ind.Alt}
 	chunk := p.Chunks[key]
+	if chunk != nil {
+		p.useChunk(key)
+	}
 	p.ChunksMutex.Unlock()
 
 	if chunk != nil && chunk.WaitingForData {
@@ -90,58 +155,7 @@ func (p *Planet) GetChunk(ind pb.ChunkIndex, async bool) *pb.Chunk {
 	}
 	if chunk == nil {
 		if p.grpcClient == nil {
-			if p.db != nil {
-				p.databaseMutex.Lock()
-				rows, e := p.db.Query("SELECT data FROM chunk WHERE planet = ? AND lon = ? AND lat = ? AND alt = ?", p.Spec.Id, ind.Lon, ind.Lat, ind.Alt)
-				if e != nil {
-					panic(e)
-				}
-				if rows.Next() {
-					var data []byte
-					e = rows.Scan(&data)
-					if e != nil {
-						panic(e)
-					}
-					var dbuf bytes.Buffer
-					dbuf.Write(data)
-					dec := gob.NewDecoder(&dbuf)
-					var ch pb.Chunk
-					e = dec.Decode(&ch)
-					if e != nil {
-						panic(e)
-					}
-					chunk = &ch
-				}
-				rows.Close()
-				p.databaseMutex.Unlock()
-				if chunk == nil {
-					chunk = newChunk(ind, p)
-					p.databaseMutex.Lock()
-					stmt, e := p.db.Prepare("INSERT INTO chunk VALUES (?, ?, ?, ?, ?)")
-					if e != nil {
-						panic(e)
-					}
-					var buf bytes.Buffer
-					enc := gob.NewEncoder(&buf)
-					e = enc.Encode(chunk)
-					if e != nil {
-						panic(e)
-					}
-					_, e = stmt.Exec(p.Spec.Id, ind.Lon, ind.Lat, ind.Alt, buf.Bytes())
-					if e != nil {
-						panic(e)
-					}
-					p.databaseMutex.Unlock()
-				}
-				p.ChunksMutex.Lock()
-				p.Chunks[key] = chunk
-				p.ChunksMutex.Unlock()
-			} else {
-				chunk = newChunk(ind, p)
-				p.ChunksMutex.Lock()
-				p.Chunks[key] = chunk
-				p.ChunksMutex.Unlock()
-			}
+			chunk = p.loadChunk(ind, key)
 		} else {
 			request := pb.GetChunkRequest{Planet: p.Spec.Id, Index: &ind}
 			if async {
@@ -152,13 +166,9 @@ func (p *Planet) GetChunk(ind pb.ChunkIndex, async bool) *pb.Chunk {
 					if err != nil {
 						log.Fatalf("get chunk failed: %v", err)
 					}
-					p.ChunksMutex.Lock()
-					p.Chunks[key] = response.Chunk
-					p.ChunksMutex.Unlock()
+					p.cacheChunk(key, response.Chunk)
 				}()
-				p.ChunksMutex.Lock()
-				p.Chunks[key] = &pb.Chunk{WaitingForData: true}
-				p.ChunksMutex.Unlock()
+				p.cacheChunk(key, &pb.Chunk{WaitingForData: true})
 			} else {
 				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 				defer cancel()
@@ -166,15 +176,112 @@ func (p *Planet) GetChunk(ind pb.ChunkIndex, async bool) *pb.Chunk {
 				if err != nil {
 					log.Fatalf("get chunk failed: %v", err)
 				}
-				p.ChunksMutex.Lock()
-				p.Chunks[key] = response.Chunk
-				p.ChunksMutex.Unlock()
+				p.cacheChunk(key, response.Chunk)
 			}
 		}
 	}
 	return chunk
 }
 
+// useChunk marks a chunk as the most recently used, and must be called holding ChunksMutex
+func (p *Planet) useChunk(key ChunkKey) {
+	p.useCount++
+	p.chunkUses[key] = p.useCount
+}
+
+// loadChunk loads a chunk from the store, or generates it if it was never stored, and keeps it in memory.
+// A generated chunk is only stored once it is changed, since until then it can always be generated again.
+func (p *Planet) loadChunk(ind pb.ChunkIndex, key ChunkKey) *pb.Chunk {
+	for {
+		p.ChunksMutex.Lock()
+		evictions := p.evictions
+		p.ChunksMutex.Unlock()
+		var chunk *pb.Chunk
+		if p.store != nil {
+			chunk = p.store.Load(p.Spec.Id, key)
+		}
+		if chunk == nil {
+			chunk = newChunk(ind, p)
+		}
+
+		p.ChunksMutex.Lock()
+		if cached := p.Chunks[key]; cached != nil {
+			// Another load of the chunk finished first, and its copy may have been edited since
+			p.useChunk(key)
+			p.ChunksMutex.Unlock()
+			return cached
+		}
+		if p.evictions != evictions {
+			// Changed chunks were saved while this one loaded, so it may be out of date
+			p.ChunksMutex.Unlock()
+			continue
+		}
+		p.Chunks[key] = chunk
+		p.useChunk(key)
+		full := p.MaxChunks > 0 && len(p.Chunks) > p.MaxChunks
+		p.ChunksMutex.Unlock()
+
+		if full {
+			p.evictChunks()
+		}
+		return chunk
+	}
+}
+
+// cacheChunk keeps a chunk in memory, evicting others if there are too many
+func (p *Planet) cacheChunk(key ChunkKey, chunk *pb.Chunk) {
+	p.ChunksMutex.Lock()
+	p.Chunks[key] = chunk
+	p.useChunk(key)
+	full := p.MaxChunks > 0 && len(p.Chunks) > p.MaxChunks
+	p.ChunksMutex.Unlock()
+	if full {
+		p.evictChunks()
+	}
+}
+
+// evictChunks drops the least recently used chunks from memory once there are more than MaxChunks, saving any changed ones to the store first
+func (p *Planet) evictChunks() {
+	// Take the same locks as a flush, so an older copy of a chunk is never saved over a newer one
+	p.flushMutex.Lock()
+	defer p.flushMutex.Unlock()
+	p.dirtyMutex.Lock()
+	defer p.dirtyMutex.Unlock()
+	p.ChunksMutex.Lock()
+	defer p.ChunksMutex.Unlock()
+	if len(p.Chunks) <= p.MaxChunks {
+		return
+	}
+
+	// Evict an eighth more than needed, so the next few new chunks need not evict again
+	keys := []ChunkKey{}
+	for key, chunk := range p.Chunks {
+		if !chunk.WaitingForData {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return p.chunkUses[keys[i]] < p.chunkUses[keys[j]]
+	})
+	evict := len(p.Chunks) - p.MaxChunks + p.MaxChunks/8
+	if evict > len(keys) {
+		evict = len(keys)
+	}
+	dirty := map[ChunkKey]*pb.Chunk{}
+	for _, key := range keys[:evict] {
+		if p.dirtyChunks[key] {
+			dirty[key] = p.Chunks[key]
+			delete(p.dirtyChunks, key)
+		}
+		delete(p.Chunks, key)
+		delete(p.chunkUses, key)
+	}
+	if len(dirty) > 0 {
+		p.store.SaveBatch(p.Spec.Id, dirty)
+		p.evictions++
+	}
+}
+
 // SetCellMaterial sets the contents of a cell
 func (p *Planet) SetCellMaterial(ind pb.CellIndex, material pb.Material, updateServer bool) bool {
 	cell := p.CellIndexToCell(ind)
@@ -184,7 +291,23 @@ func (p *Planet) SetCellMaterial(ind pb.CellIndex, material pb.Material, updateS
 	if cell.Material == material {
 		return false
 	}
-	cell.Material = material
+	chunkInd := p.CellIndexToChunkIndex(ind)
+	key := ChunkKey{Lon: chunkInd.Lon, Lat: chunkInd.Lat, Alt: chunkInd.Alt}
+	p.dirtyMutex.Lock()
+	p.ChunksMutex.Lock()
+	chunk := p.Chunks[key]
+	p.ChunksMutex.Unlock()
+	if chunk == nil || chunk.WaitingForData {
+		// The chunk was evicted since the cell was read, so edit it once it is loaded again
+		p.dirtyMutex.Unlock()
+		return p.SetCellMaterial(ind, material, updateServer)
+	}
+	if chunk.Uniform {
+		p.expandChunk(chunkInd, chunk)
+	}
+	p.chunkCell(chunk, ind).Material = material
+	p.markDirty(key)
+	p.dirtyMutex.Unlock()
 	if p.grpcClient != nil && updateServer {
 		go func() {
 			request := pb.SetCellMaterialRequest{Planet: p.Spec.Id, Index: &ind, Cell: &pb.Cell{Material: material}}
@@ -196,56 +319,174 @@ func (p *Planet) SetCellMaterial(ind pb.CellIndex, material pb.Material, updateS
 			}
 		}()
 	}
-	if p.db != nil {
-		chunkInd := p.CellIndexToChunkIndex(ind)
-		chunk := p.CellIndexToChunk(ind)
-		p.databaseMutex.Lock()
-		stmt, e := p.db.Prepare("UPDATE chunk SET data = ? WHERE planet = 0 AND lon = ? AND lat = ? AND alt = ?")
-		if e != nil {
-			panic(e)
+
+	return true
+}
+
+// SetCellMaterials sets the contents of many cells at once, returning how many changed.
+// The cells are changed together and saved to the store in one batch, so the store never holds only some of them.
+func (p *Planet) SetCellMaterials(cells map[CellKey]pb.Material) (int, error) {
+	if p.grpcClient != nil {
+		return 0, fmt.Errorf("cells are set in batches only on the server")
+	}
+	chunkInds := map[ChunkKey]pb.ChunkIndex{}
+	for cell := range cells {
+		ind := p.CellIndexToChunkIndex(pb.CellIndex{Lon: cell.Lon, Lat: cell.Lat, Alt: cell.Alt})
+		chunkInds[ChunkKey{Lon: ind.Lon, Lat: ind.Lat, Alt: ind.Alt}] = ind
+	}
+	if p.MaxChunks > 0 && len(chunkInds) > p.MaxChunks/2 {
+		return 0, fmt.Errorf("cells span %v chunks, more than can be kept in memory at once", len(chunkInds))
+	}
+
+	for {
+		chunks := map[ChunkKey]*pb.Chunk{}
+		for key, ind := range chunkInds {
+			chunk := p.GetChunk(ind, false)
+			if chunk == nil {
+				return 0, fmt.Errorf("chunk %v,%v,%v is not on planet %v", ind.Lon, ind.Lat, ind.Alt, p.Spec.Id)
+			}
+			chunks[key] = chunk
 		}
-		var buf bytes.Buffer
-		enc := gob.NewEncoder(&buf)
-		e = enc.Encode(chunk)
-		if e != nil {
-			panic(e)
+
+		// Hold off flushes and evictions until the batch is saved
+		p.flushMutex.Lock()
+		p.dirtyMutex.Lock()
+		p.ChunksMutex.Lock()
+		evicted := false
+		for key, chunk := range chunks {
+			if p.Chunks[key] != chunk {
+				evicted = true
+			}
 		}
-		_, e = stmt.Exec(buf.Bytes(), chunkInd.Lon, chunkInd.Lat, chunkInd.Alt)
-		if e != nil {
-			panic(e)
+		if evicted {
+			// Loading the last chunks evicted the first, so load them again
+			p.ChunksMutex.Unlock()
+			p.dirtyMutex.Unlock()
+			p.flushMutex.Unlock()
+			continue
 		}
-		p.databaseMutex.Unlock()
+
+		changed := 0
+		batch := map[ChunkKey]*pb.Chunk{}
+		for cell, material := range cells {
+			ind := pb.CellIndex{Lon: cell.Lon, Lat: cell.Lat, Alt: cell.Alt}
+			chunkInd := p.CellIndexToChunkIndex(ind)
+			key := ChunkKey{Lon: chunkInd.Lon, Lat: chunkInd.Lat, Alt: chunkInd.Alt}
+			chunk := chunks[key]
+			if chunk.Uniform {
+				if chunk.Material == material {
+					continue
+				}
+				p.expandChunk(chunkInd, chunk)
+			}
+			if c := p.chunkCell(chunk, ind); c.Material != material {
+				c.Material = material
+				batch[key] = chunk
+				changed++
+			}
+		}
+		for key, chunk := range batch {
+			// The whole chunk is saved below, so it is no longer dirty
+			batch[key] = proto.Clone(chunk).(*pb.Chunk)
+			delete(p.dirtyChunks, key)
+		}
+		p.ChunksMutex.Unlock()
+		p.dirtyMutex.Unlock()
+		if p.store != nil && len(batch) > 0 {
+			p.store.SaveBatch(p.Spec.Id, batch)
+		}
+		p.flushMutex.Unlock()
+		return changed, nil
 	}
+}
 
-	return true
+// markDirty records that a chunk needs saving to the store, and must be called holding dirtyMutex
+func (p *Planet) markDirty(key ChunkKey) {
+	if p.store != nil {
+		p.dirtyChunks[key] = true
+	}
+}
+
+// DirtyChunks returns the number of chunks changed since they were last saved to the store
+func (p *Planet) DirtyChunks() int {
+	p.dirtyMutex.Lock()
+	defer p.dirtyMutex.Unlock()
+	return len(p.dirtyChunks)
+}
+
+// FlushChunks saves the chunks changed since they were last saved to the store in one batch, returning how many it saved
+func (p *Planet) FlushChunks() int {
+	// Flushes take turns, so an older copy of a chunk is never saved over a newer one
+	p.flushMutex.Lock()
+	defer p.flushMutex.Unlock()
+	p.dirtyMutex.Lock()
+	batch := make(map[ChunkKey]*pb.Chunk, len(p.dirtyChunks))
+	p.ChunksMutex.Lock()
+	for key := range p.dirtyChunks {
+		// Save copies, so edits can carry on while the batch is written
+		if chunk := p.Chunks[key]; chunk != nil {
+			batch[key] = proto.Clone(chunk).(*pb.Chunk)
+		}
+	}
+	p.ChunksMutex.Unlock()
+	p.dirtyChunks = make(map[ChunkKey]bool)
+	p.dirtyMutex.Unlock()
+	if len(batch) > 0 {
+		p.store.SaveBatch(p.Spec.Id, batch)
+	}
+	return len(batch)
 }
 
-func (p *Planet) validateCellLoc(l pb.CellLoc) pb.CellLoc {
-	if l.Lon < 0 {
-		l.Lon += float64(p.LonCells)
+// PruneChunks deletes the stored chunks that match the chunks generated in their place, returning how many it deleted
+func (p *Planet) PruneChunks() int {
+	if p.store == nil {
+		return 0
 	}
-	for l.Lon >= float64(p.LonCells) {
-		l.Lon -= float64(p.LonCells)
+	p.FlushChunks()
+	pruned := 0
+	for _, key := range p.store.List(p.Spec.Id) {
+		ind := pb.ChunkIndex{Lon: key.Lon, Lat: key.Lat, Alt: key.Alt}
+		if p.chunksMatch(ind, p.store.Load(p.Spec.Id, key), newChunk(ind, p)) {
+			p.store.Delete(p.Spec.Id, key)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// chunksMatch returns whether two copies of a chunk hold the same materials in every cell
+func (p *Planet) chunksMatch(ind pb.ChunkIndex, a, b *pb.Chunk) bool {
+	if a.Uniform && b.Uniform {
+		return a.Material == b.Material
+	}
+	lonCells, latCells := p.LonLatCellsInChunkIndex(ind)
+	for lon := 0; lon < lonCells; lon++ {
+		for lat := 0; lat < latCells; lat++ {
+			for alt := 0; alt < int(p.ChunkSize); alt++ {
+				if ChunkMaterial(a, lon, lat, alt) != ChunkMaterial(b, lon, lat, alt) {
+					return false
+				}
+			}
+		}
 	}
-	return l
+	return true
 }
 
 // CellLocToChunk converts floating-point cell indices to a chunk
 func (p *Planet) CellLocToChunk(l pb.CellLoc) *pb.Chunk {
-	l = p.validateCellLoc(l)
 	return p.CellIndexToChunk(p.CellLocToCellIndex(l))
 }
 
 // CellIndexToChunk converts a cell index to its containing chunk
 func (p *Planet) CellIndexToChunk(cellIndex pb.CellIndex) *pb.Chunk {
 	ind := p.CellIndexToChunkIndex(cellIndex)
-	if ind.Lon < 0 || ind.Lon >= p.LonCells/ChunkSize {
+	if ind.Lon < 0 || ind.Lon >= p.LonCells/p.ChunkSize {
 		return nil
 	}
-	if ind.Lat < 0 || ind.Lat >= p.LatCells/ChunkSize {
+	if ind.Lat < 0 || ind.Lat >= p.LatCells/p.ChunkSize {
 		return nil
 	}
-	if ind.Alt < 0 || ind.Alt >= p.Spec.AltCells/ChunkSize {
+	if ind.Alt < 0 || ind.Alt >= p.Spec.AltCells/p.ChunkSize {
 		return nil
 	}
 	return p.GetChunk(ind, true)
@@ -253,13 +494,12 @@ func (p *Planet) CellIndexToChunk(cellIndex pb.CellIndex) *pb.Chunk {
 
 // CellLocToChunkIndex converts floating-point cell indices to a chunk index
 func (p *Planet) CellLocToChunkIndex(l pb.CellLoc) pb.ChunkIndex {
-	l = p.validateCellLoc(l)
 	return p.CellIndexToChunkIndex(p.CellLocToCellIndex(l))
 }
 
 // CellIndexToChunkIndex converts a cell index to its containing chunk index
 func (p *Planet) CellIndexToChunkIndex(cellInd pb.CellIndex) pb.ChunkIndex {
-	cs := float64(ChunkSize)
+	cs := float64(p.ChunkSize)
 	return pb.ChunkIndex{
 		Lon: int64(math.Floor(float64(cellInd.Lon) / cs)),
 		Lat: int64(math.Floor(float64(cellInd.Lat) / cs)),
@@ -267,11 +507,37 @@ func (p *Planet) CellIndexToChunkIndex(cellInd pb.CellIndex) pb.ChunkIndex {
 	}
 }
 
+// AdjacentChunkKeys returns the keys of the other chunks touching a cell, whose geometry depends on whether the cell is solid
+func (p *Planet) AdjacentChunkKeys(ind pb.CellIndex) []ChunkKey {
+	own := p.CellIndexToChunkIndex(ind)
+	lonMin, lonMax, latMin, latMax, _, _ := p.cellBounds(ind)
+	keys := []ChunkKey{}
+	for _, step := range []struct {
+		lon, lat         int64
+		dLon, dLat, dAlt int64
+	}{
+		{int64(lonMin + 0.5), ind.Lat, -1, 0, 0},
+		{int64(lonMax - 0.5), ind.Lat, 1, 0, 0},
+		{ind.Lon, int64(latMin + 0.5), 0, -1, 0},
+		{ind.Lon, int64(latMax - 0.5), 0, 1, 0},
+		{ind.Lon, ind.Lat, 0, 0, -1},
+		{ind.Lon, ind.Lat, 0, 0, 1},
+	} {
+		neighbor, ok := p.NeighborCellIndex(pb.CellIndex{Lon: step.lon, Lat: step.lat, Alt: ind.Alt}, step.dLon, step.dLat, step.dAlt)
+		if !ok {
+			continue
+		}
+		chunk := p.CellIndexToChunkIndex(neighbor)
+		if chunk.Lon != own.Lon || chunk.Lat != own.Lat || chunk.Alt != own.Alt {
+			keys = append(keys, ChunkKey{Lon: chunk.Lon, Lat: chunk.Lat, Alt: chunk.Alt})
+		}
+	}
+	return keys
+}
+
 // CellLocToCellIndex converts floating-point cell indices to a cell index
 func (p *Planet) CellLocToCellIndex(l pb.CellLoc) pb.CellIndex {
-	l = p.validateCellLoc(l)
 	l = p.CellLocToNearestCellCenter(l)
-	l = p.validateCellLoc(l)
 	return pb.CellIndex{Lon: int64(l.Lon), Lat: int64(l.Lat), Alt: int64(l.Alt)}
 }
 
@@ -296,60 +562,48 @@ func (p *Planet) CartesianToChunk(cart mgl32.Vec3) *pb.Chunk {
 	return p.CellLocToChunk(p.CartesianToCellLoc(cart))
 }
 
-// CellLocToNearestCellCenter converts floating-point cell indices to the nearest integral indices
+// CellLocToNearestCellCenter converts floating-point cell indices to the integral indices of the cell holding them
 func (p *Planet) CellLocToNearestCellCenter(l pb.CellLoc) pb.CellLoc {
-	l = p.validateCellLoc(l)
-	return pb.CellLoc{
-		Lon: float64(math.Floor(float64(l.Lon) + 0.5)),
-		Lat: float64(math.Floor(float64(l.Lat) + 0.5)),
-		Alt: float64(math.Floor(float64(l.Alt) + 0.5)),
-	}
+	return p.Topology.NearestCellCenter(l)
 }
 
 // CellLocToCell converts floating-point chunk indices to a cell
 func (p *Planet) CellLocToCell(l pb.CellLoc) *pb.Cell {
-	l = p.validateCellLoc(l)
 	return p.CellIndexToCell(p.CellLocToCellIndex(l))
 }
 
 // CellIndexToCell converts a cell index to a cell
 func (p *Planet) CellIndexToCell(cellIndex pb.CellIndex) *pb.Cell {
-	chunkIndex := p.CellIndexToChunkIndex(cellIndex)
-	lonCells, latCells := p.LonLatCellsInChunkIndex(chunkIndex)
-	lonWidth := ChunkSize / lonCells
-	latWidth := ChunkSize / latCells
 	chunk := p.CellIndexToChunk(cellIndex)
 	if chunk == nil {
 		return nil
 	}
-	lonInd := (cellIndex.Lon % ChunkSize) / int64(lonWidth)
-	latInd := (cellIndex.Lat % ChunkSize) / int64(latWidth)
-	altInd := cellIndex.Alt % ChunkSize
-	return chunk.Cell[lonInd].Cell[latInd].Cell[altInd]
+	if chunk.Uniform {
+		return &pb.Cell{Material: chunk.Material}
+	}
+	return p.chunkCell(chunk, cellIndex)
 }
 
-// SphericalToCellLoc converts spherical coordinates to floating-point cell indices
-func (p *Planet) SphericalToCellLoc(r, theta, phi float32) pb.CellLoc {
-	alt := (r - float32(p.AltMin)) / float32(p.AltDelta)
-	lat := (180*theta/math.Pi-90+float32(p.LatMax))*float32(p.LatCells)/(2*float32(p.LatMax)) - 0.5
-	if phi < 0 {
-		phi += 2 * math.Pi
-	}
-	lon := phi * float32(p.LonCells) / (2 * math.Pi)
-	return pb.CellLoc{Lon: float64(lon), Lat: float64(lat), Alt: float64(alt)}
+// chunkCell returns a cell of a chunk that is not uniform
+func (p *Planet) chunkCell(chunk *pb.Chunk, cellIndex pb.CellIndex) *pb.Cell {
+	chunkIndex := p.CellIndexToChunkIndex(cellIndex)
+	lonCells, latCells := p.LonLatCellsInChunkIndex(chunkIndex)
+	lonWidth := int(p.ChunkSize) / lonCells
+	latWidth := int(p.ChunkSize) / latCells
+	lonInd := (cellIndex.Lon % p.ChunkSize) / int64(lonWidth)
+	latInd := (cellIndex.Lat % p.ChunkSize) / int64(latWidth)
+	altInd := cellIndex.Alt % p.ChunkSize
+	return chunk.Cell[lonInd].Cell[latInd].Cell[altInd]
 }
 
 // CartesianToCell returns the cell contianing a set of world coordinates
 func (p *Planet) CartesianToCell(cart mgl32.Vec3) *pb.Cell {
-	r, theta, phi := mgl32.CartesianToSpherical(cart)
-	l := p.SphericalToCellLoc(r, theta, phi)
-	return p.CellLocToCell(l)
+	return p.CellLocToCell(p.CartesianToCellLoc(cart))
 }
 
 // CartesianToCellLoc converts world coordinates to floating-point cell indices
 func (p *Planet) CartesianToCellLoc(cart mgl32.Vec3) pb.CellLoc {
-	r, theta, phi := mgl32.CartesianToSpherical(cart)
-	return p.SphericalToCellLoc(r, theta, phi)
+	return p.Topology.CartesianToCellLoc(cart)
 }
 
 // CellIndexToCartesian converts a cell index to world coordinates
@@ -360,66 +614,125 @@ func (p *Planet) CellIndexToCartesian(ind pb.CellIndex) mgl32.Vec3 {
 
 // CellLocToCartesian converts floating-point cell indices to world coordinates
 func (p *Planet) CellLocToCartesian(l pb.CellLoc) mgl32.Vec3 {
-	l = p.validateCellLoc(l)
-	r, theta, phi := p.CellLocToSpherical(l)
-	return mgl32.SphericalToCartesian(r, theta, phi)
+	return p.Topology.CellLocToCartesian(l)
 }
 
-// CellLocToSpherical converts floating-point cell indices to spherical coordinates
-func (p *Planet) CellLocToSpherical(l pb.CellLoc) (r, theta, phi float32) {
-	l = p.validateCellLoc(l)
-	r = float32(l.Alt)*float32(p.AltDelta) + float32(p.AltMin)
-	theta = (math.Pi / 180) * ((90.0 - float32(p.LatMax)) + ((float32(l.Lat)+0.5)/float32(p.LatCells))*(2.0*float32(p.LatMax)))
-	phi = 2 * math.Pi * float32(l.Lon) / float32(p.LonCells)
-	return
+// CellVertexToCartesian converts floating-point cell indices near a cell, such as its corners, to world coordinates
+func (p *Planet) CellVertexToCartesian(ind pb.CellIndex, l pb.CellLoc) mgl32.Vec3 {
+	return p.Topology.CellVertexToCartesian(ind, l)
+}
+
+// NeighborCellIndex returns the cell index a number of cells away from another along each axis, and false if it is off the planet
+func (p *Planet) NeighborCellIndex(ind pb.CellIndex, dLon, dLat, dAlt int64) (pb.CellIndex, bool) {
+	return p.Topology.NeighborCellIndex(ind, dLon, dLat, dAlt)
 }
 
 // LonLatCellsInChunkIndex returns the number of longitude and latitude cells in a chunk, which changes based on latitude and altitude
 func (p *Planet) LonLatCellsInChunkIndex(ind pb.ChunkIndex) (lonCells, latCells int) {
-	lonCells = ChunkSize
-	latCells = ChunkSize
+	return p.Topology.LonLatCellsInChunkIndex(ind)
+}
+
+// Up returns the unit vector pointing away from the ground at world coordinates
+func (p *Planet) Up(cart mgl32.Vec3) mgl32.Vec3 {
+	return p.Topology.Up(cart)
+}
 
-	// If chunk is too close to the poles, lower the longitude cells per chunk
-	theta := (90.0 - float32(p.LatMax) + (float32(ind.Lat)+0.5)*float32(ChunkSize)/float32(p.LatCells)) * (2.0 * float32(p.LatMax))
-	if math.Abs(float64(theta-90)) >= 60 {
-		lonCells /= 2
+// ChunkMaterial returns the material of a cell by its position within a chunk
+func ChunkMaterial(chunk *pb.Chunk, lon, lat, alt int) pb.Material {
+	if chunk.Uniform {
+		return chunk.Material
 	}
-	if math.Abs(float64(theta-90)) >= 80 {
-		lonCells /= 2
+	return chunk.Cell[lon].Cell[lat].Cell[alt].Material
+}
+
+// uniformChunkMaterial returns the material filling every cell of a chunk, and false if the chunk may hold
+// more than one material. It is decided from the bounds of the planet's terrain, without generating any cells.
+func (p *Planet) uniformChunkMaterial(ind pb.ChunkIndex) (pb.Material, bool) {
+	if p.bounds == nil {
+		return pb.Material_AIR, false
 	}
+	b := p.bounds(p)
+	altLo := float64(ind.Alt * p.ChunkSize)
+	altHi := altLo + float64(p.ChunkSize-1)
 
-	// If chunk is too close to the center of the planet, lower both lon and lat cells per chunk
-	if (float64(ind.Alt)+0.5)*ChunkSize < p.Spec.Radius/4 {
-		lonCells /= 2
-		latCells /= 2
+	// The planet always has a solid core
+	if altLo < 2 && b.Fill != pb.Material_STONE {
+		return pb.Material_AIR, false
 	}
-	if (float64(ind.Alt)+0.5)*ChunkSize < p.Spec.Radius/8 {
-		lonCells /= 2
-		latCells /= 2
+	if altLo >= b.Air && altLo >= 2 {
+		return pb.Material_AIR, true
+	}
+	if altHi >= b.Solid {
+		return pb.Material_AIR, false
 	}
 
+	// Ores may be scattered through the fill at the depths they are found
+	half := float64(p.Spec.AltCells) / 2
+	depthLo, depthHi := 1-altHi/half, 1-altLo/half
+	for _, d := range p.Ores {
+		if d.Host == b.Fill && depthHi >= d.MinDepth && depthLo <= d.MaxDepth {
+			return pb.Material_AIR, false
+		}
+	}
+	return b.Fill, true
+}
+
+// surfaceAltChunks returns the range of altitude chunk indices that may hold the surface of the terrain
+func (p *Planet) surfaceAltChunks() (lo, hi int64) {
+	lo, hi = 0, p.Spec.AltCells/p.ChunkSize-1
+	if p.bounds == nil {
+		return
+	}
+	b := p.bounds(p)
+	cs := float64(p.ChunkSize)
+	lo = int64(math.Max(float64(lo), math.Floor((b.Solid-1)/cs)))
+	hi = int64(math.Min(float64(hi), math.Floor(b.Air/cs)))
 	return
 }
 
+// expandChunk fills in every cell of a uniform chunk, so that its cells may be changed one at a time
+func (p *Planet) expandChunk(ind pb.ChunkIndex, chunk *pb.Chunk) {
+	lonCells, latCells := p.LonLatCellsInChunkIndex(ind)
+	cells := make([]*pb.Chunk_CellLat, lonCells)
+	for lonIndex := range cells {
+		cells[lonIndex] = &pb.Chunk_CellLat{Cell: make([]*pb.Chunk_CellAlt, latCells)}
+		for latIndex := range cells[lonIndex].Cell {
+			alts := make([]*pb.Cell, p.ChunkSize)
+			for altIndex := range alts {
+				alts[altIndex] = &pb.Cell{Material: chunk.Material}
+			}
+			cells[lonIndex].Cell[latIndex] = &pb.Chunk_CellAlt{Cell: alts}
+		}
+	}
+	chunk.Cell = cells
+	chunk.Uniform = false
+	chunk.Material = pb.Material_AIR
+}
+
 func newChunk(ind pb.ChunkIndex, p *Planet) *pb.Chunk {
+	if material, ok := p.uniformChunkMaterial(ind); ok {
+		return &pb.Chunk{Uniform: true, Material: material}
+	}
 	chunk := pb.Chunk{}
 	lonCells, latCells := p.LonLatCellsInChunkIndex(ind)
-	lonWidth := ChunkSize / lonCells
-	latWidth := ChunkSize / latCells
+	cs := int(p.ChunkSize)
+	lonWidth := cs / lonCells
+	latWidth := cs / latCells
 	chunk.Cell = make([]*pb.Chunk_CellLat, lonCells)
 	for lonIndex := 0; lonIndex < lonCells; lonIndex++ {
 		chunk.Cell[lonIndex] = &pb.Chunk_CellLat{}
 		chunk.Cell[lonIndex].Cell = make([]*pb.Chunk_CellAlt, latCells)
 		for latIndex := 0; latIndex < latCells; latIndex++ {
 			chunk.Cell[lonIndex].Cell[latIndex] = &pb.Chunk_CellAlt{}
-			chunk.Cell[lonIndex].Cell[latIndex].Cell = make([]*pb.Cell, ChunkSize)
-			for altIndex := 0; altIndex < ChunkSize; altIndex++ {
+			chunk.Cell[lonIndex].Cell[latIndex].Cell = make([]*pb.Cell, cs)
+			for altIndex := 0; altIndex < cs; altIndex++ {
 				l := pb.CellLoc{
-					Lon: float64(int(ChunkSize*ind.Lon) + lonIndex*lonWidth),
-					Lat: float64(int(ChunkSize*ind.Lat) + latIndex*latWidth),
-					Alt: float64(int(ChunkSize*ind.Alt) + altIndex),
+					Lon: float64(cs*int(ind.Lon) + lonIndex*lonWidth),
+					Lat: float64(cs*int(ind.Lat) + latIndex*latWidth),
+					Alt: float64(cs*int(ind.Alt) + altIndex),
 				}
 				c := p.Generator(p, l)
+				c.Material = p.ore(l, c.Material)
 
 				// Always give the planet a solid core
 				if l.Alt < 2 {
@@ -430,6 +743,7 @@ func newChunk(ind pb.ChunkIndex, p *Planet) *pb.Chunk {
 			}
 		}
 	}
+	p.decorateChunk(ind, &chunk)
 	return &chunk
 }
 
@@ -452,6 +766,15 @@ var (
 		"yellow_block",
 		"yellow_sand",
 		"water",
+		"blue_wood",
+		"green_wood",
+		"purple_wood",
+		"yellow_wood",
+		"blue_leaves",
+		"coal_ore",
+		"iron_ore",
+		"gold_ore",
+		"crystal_ore",
 	}
 	MaterialColors = []mgl32.Vec3{
 		{0.0, 0.0, 0.0},
@@ -470,6 +793,15 @@ var (
 		{1.0, 1.0, 0.0},
 		{1.0, 1.0, 0.0},
 		{0.0, 0.0, 0.0},
+		{0.3, 0.4, 0.9},
+		{0.3, 0.8, 0.3},
+		{0.7, 0.3, 0.8},
+		{0.9, 0.8, 0.2},
+		{0.4, 0.6, 1.0},
+		{0.2, 0.2, 0.2},
+		{0.9, 0.7, 0.5},
+		{1.0, 0.9, 0.3},
+		{0.6, 1.0, 0.9},
 	}
 )
 
@@ -479,18 +811,17 @@ func (p *Planet) generateGeometry() *pb.PlanetGeometry {
 	latCells := 32 + 1
 	geom.Material = make([]*pb.PlanetGeometry_MaterialRow, lonCells)
 	geom.Altitude = make([]*pb.PlanetGeometry_AltitudeRow, lonCells)
+	geom.Biome = make([]*pb.PlanetGeometry_BiomeRow, lonCells)
 	for lon := 0; lon < lonCells; lon++ {
 		geom.Material[lon] = &pb.PlanetGeometry_MaterialRow{}
 		geom.Material[lon].Material = make([]pb.Material, latCells)
 		geom.Altitude[lon] = &pb.PlanetGeometry_AltitudeRow{}
 		geom.Altitude[lon].Altitude = make([]int64, latCells)
+		geom.Biome[lon] = &pb.PlanetGeometry_BiomeRow{}
+		geom.Biome[lon].Biome = make([]pb.Biome, latCells)
 		for lat := 0; lat < latCells; lat++ {
-			lonInd := math.Floor(float64(p.LonCells) * float64(lon) / float64(lonCells))
-
-			// Make sure latitude hits both poles, hence the need for division by (latCells - 1)
-			latInd := math.Floor(float64(p.LatCells) * float64(lat) / float64(latCells-1))
-
-			loc := pb.CellLoc{Lon: float64(lonInd), Lat: float64(latInd), Alt: float64(p.Spec.AltCells - 1)}
+			ind := p.GeometrySampleCellIndex(lon, lat, lonCells, latCells)
+			loc := pb.CellLoc{Lon: float64(ind.Lon), Lat: float64(ind.Lat), Alt: float64(p.Spec.AltCells - 1)}
 			cell := p.Generator(p, loc)
 			for cell.Material == pb.Material_AIR && loc.Alt > 0 {
 				loc.Alt--
@@ -498,11 +829,26 @@ func (p *Planet) generateGeometry() *pb.PlanetGeometry {
 			}
 			geom.Material[lon].Material[lat] = cell.Material
 			geom.Altitude[lon].Altitude[lat] = int64(loc.Alt)
+			geom.Biome[lon].Biome[lat] = p.BiomeAt(loc)
 		}
 	}
 	return &geom
 }
 
+// GeometrySampleCellIndex returns the column of cells, at altitude zero, under a sample of the low-resolution geometry
+func (p *Planet) GeometrySampleCellIndex(lon, lat, lonSamples, latSamples int) pb.CellIndex {
+	ind := p.CellLocToCellIndex(p.Topology.geometrySampleLoc(lon, lat, lonSamples, latSamples))
+	ind.Alt = 0
+	return ind
+}
+
+// GeometrySampleToCartesian returns the world coordinates of a sample of the low-resolution geometry at an altitude
+func (p *Planet) GeometrySampleToCartesian(lon, lat, lonSamples, latSamples int, alt int64) mgl32.Vec3 {
+	l := p.Topology.geometrySampleLoc(lon, lat, lonSamples, latSamples)
+	l.Alt = float64(alt)
+	return p.CellLocToCartesian(l)
+}
+
 // GetGeometry returns the low-resultion geometry for the planet.
 func (p *Planet) GetGeometry(async bool) *pb.PlanetGeometry {
 	if p.Geometry != nil && p.Geometry.IsLoading {
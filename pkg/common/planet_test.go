@@ -0,0 +1,43 @@
+package common
+
+import (
+	"testing"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+func TestNewPlanetChunkSize(t *testing.T) {
+	tests := []struct {
+		chunkSize int64
+		want      int64
+		valid     bool
+	}{
+		{0, DefaultChunkSize, true},
+		{8, 8, true},
+		{32, 32, true},
+		{24, 16, false},
+		{12, 8, false},
+		{-16, DefaultChunkSize, false},
+	}
+	for _, test := range tests {
+		spec := pb.PlanetSpec{Radius: 64, AltCells: 64, ChunkSize: test.chunkSize}
+		if err := ValidatePlanetSpec(&spec); (err == nil) != test.valid {
+			t.Errorf("chunk size %v: validation error %v, want valid %v", test.chunkSize, err, test.valid)
+		}
+
+		// Planets are made of any spec, even one that would not be valid
+		if p := NewPlanet(nil, nil, spec); p.ChunkSize != test.want {
+			t.Errorf("chunk size %v became %v, want %v", test.chunkSize, p.ChunkSize, test.want)
+		}
+	}
+}
+
+func TestSystemsAreValid(t *testing.T) {
+	for name, system := range systems {
+		for _, spec := range system(1) {
+			if err := ValidatePlanetSpec(spec); err != nil {
+				t.Errorf("%v: %v", name, err)
+			}
+		}
+	}
+}
@@ -25,6 +25,12 @@ const (
 	MaxHealth = 10
 )
 
+// spaceThrust is the acceleration in space per unit of movement velocity
+const spaceThrust = 2.0
+
+// atmosphereDrag is the fraction of falling speed lost per second in an atmosphere of density 1
+const atmosphereDrag = 0.5
+
 // Player represents a player of the game
 type Player struct {
 	Planet           *Planet
@@ -36,7 +42,11 @@ type Player struct {
 	LeftVel          float32
 	FallVel          float32
 	WalkVel          float32
+	JumpVel          float32
+	Reach            float32
 	loc              mgl32.Vec3
+	prevLoc          mgl32.Vec3
+	renderLoc        mgl32.Vec3
 	lookHeading      mgl32.Vec3
 	lookAltitude     float64
 	height           float64
@@ -45,6 +55,7 @@ type Player struct {
 	GameMode         int
 	HoldingJump      bool
 	inJump           bool
+	onGround         bool
 	Name             string
 	ActiveHotBarSlot int
 	FocusCellIndex   pb.CellIndex
@@ -52,10 +63,17 @@ type Player struct {
 	Hotbar           [12]Slot
 	Inventory        [48]Slot
 	renderDistance   int
+	renderAltitude   int
 	Health           int
 	Text             string
 	DrawText         string
 	Mode             string
+	Universe         *Universe
+	inSpace          bool
+	spaceLoc         mgl32.Vec3
+	spaceVel         mgl32.Vec3
+	vel              mgl32.Vec3
+	time             float64
 }
 
 // Slot is an inventory slot
@@ -75,6 +93,8 @@ type HitPlayerArgs struct {
 func NewPlayer(name string) *Player {
 	p := Player{}
 	p.WalkVel = 5.0
+	p.JumpVel = 7.0
+	p.Reach = 5.0
 	p.height = 2
 	p.radius = 0.25
 	p.MovementMode = Normal
@@ -83,6 +103,7 @@ func NewPlayer(name string) *Player {
 	p.ActiveHotBarSlot = 0
 	p.HotbarOn = true
 	p.renderDistance = 4
+	p.renderAltitude = 2
 	return &p
 }
 
@@ -97,20 +118,31 @@ func (player *Player) Spawn() {
 	player.RightVel = 0
 	player.LeftVel = 0
 	player.FallVel = 0
-	loc := mgl32.Vec3{float32(player.Planet.Spec.Radius) + 5, 0, 0}
+	player.inSpace = false
+	player.onGround = false
+	player.vel = mgl32.Vec3{}
+	planet := player.Planet
+	spawn := planet.Topology.SpawnLoc()
+	spawn.Alt = float64(planet.Spec.AltCells) + 5/planet.AltDelta
+	loc := planet.CellLocToCartesian(spawn)
 	player.loc = loc
 
 	// Make sure the spawn area is ready (not async)
 	player.LoadNearbyChunks(false)
 
 	// Find a non-air place to land
-	c := player.Planet.CartesianToCell(loc)
-	for (c == nil || c.Material == pb.Material_AIR) && loc[0] > 0 {
-		loc[0]--
-		c = player.Planet.CartesianToCell(loc)
+	up := planet.Up(loc)
+	c := planet.CartesianToCell(loc)
+	for (c == nil || c.Material == pb.Material_AIR) && planet.CartesianToCellLoc(loc).Alt > 0 {
+		loc = loc.Sub(up)
+		c = planet.CartesianToCell(loc)
 	}
-	loc[0] += 5
+	loc = loc.Add(up.Mul(5))
 	player.loc = loc
+
+	// A respawn is a jump, so the simulation should not blend from where the player was
+	player.prevLoc = loc
+	player.renderLoc = loc
 }
 
 // Location returns the location of the player.
@@ -121,6 +153,14 @@ func (player *Player) Location() mgl32.Vec3 {
 	return player.loc
 }
 
+// RenderLocation returns the location at which to draw the player, between the last two simulation steps.
+func (player *Player) RenderLocation() mgl32.Vec3 {
+	if player.Mode == "Apex" {
+		return player.Location()
+	}
+	return player.renderLoc
+}
+
 // SetLocation sets the location of the player.
 func (player *Player) SetLocation(loc mgl32.Vec3) {
 	if !(player.Mode == "Apex") {
@@ -141,7 +181,7 @@ func (player *Player) UpdateHealth(amount int) {
 
 // LookDir returns the player's look direction
 func (player *Player) LookDir() mgl32.Vec3 {
-	up := player.Location().Normalize()
+	up := player.Planet.Up(player.Location())
 	player.lookHeading = ProjectToPlane(player.lookHeading, up).Normalize()
 	right := player.lookHeading.Cross(up)
 	return mgl32.QuatRotate(float32((player.lookAltitude-90.0)*math.Pi/180.0), right).Rotate(up)
@@ -150,7 +190,7 @@ func (player *Player) LookDir() mgl32.Vec3 {
 // Swivel swivels the player's direction based on mouse movement
 func (player *Player) Swivel(deltaX float64, deltaY float64) {
 	lookHeadingDelta := -0.1 * deltaX
-	normalDir := player.Location().Normalize()
+	normalDir := player.Planet.Up(player.Location())
 	player.lookHeading = mgl32.QuatRotate(float32(lookHeadingDelta*math.Pi/180.0), normalDir).Rotate(player.lookHeading)
 	player.lookAltitude = player.lookAltitude - 0.1*deltaY
 	player.lookAltitude = math.Max(math.Min(player.lookAltitude, 89.9), -89.9)
@@ -159,126 +199,145 @@ func (player *Player) Swivel(deltaX float64, deltaY float64) {
 // LoadNearbyChunks loads the chunks around the player, either synchronously or asynchronously
 func (player *Player) LoadNearbyChunks(async bool) {
 	planet := player.Planet
-	up := player.Location().Normalize()
+	up := planet.Up(player.Location())
 	feet := player.Location().Sub(up.Mul(float32(player.height)))
-	ind := planet.CartesianToChunkIndex(feet)
-	for lon := int(ind.Lon) - player.renderDistance; lon <= int(ind.Lon)+player.renderDistance; lon++ {
-		validLon := lon
-		for validLon < 0 {
-			validLon += int(planet.LonCells) / ChunkSize
-		}
-		for validLon >= int(planet.LonCells)/ChunkSize {
-			validLon -= int(planet.LonCells) / ChunkSize
+	center := planet.CartesianToCellIndex(feet)
+	center.Lat = int64(Max(0, Min(int(center.Lat), int(planet.LatCells)-1)))
+
+	// Only load the altitudes near the player, and those holding the surface so that it can be seen from above or below
+	playerAlt := planet.CellIndexToChunkIndex(center).Alt
+	surfaceLo, surfaceHi := planet.surfaceAltChunks()
+	alts := []int64{}
+	for alt := int64(0); alt < planet.Spec.AltCells/planet.ChunkSize; alt++ {
+		near := alt >= playerAlt-int64(player.renderAltitude) && alt <= playerAlt+int64(player.renderAltitude)
+		if near || (alt >= surfaceLo && alt <= surfaceHi) {
+			alts = append(alts, alt)
 		}
-		latMin := Max(int(ind.Lat)-player.renderDistance, 0)
-		latMax := Min(int(ind.Lat)+player.renderDistance, int(planet.LatCells)/ChunkSize-1)
-		for lat := latMin; lat <= latMax; lat++ {
-			for alt := 0; alt < int(planet.Spec.AltCells)/ChunkSize; alt++ {
-				planet.GetChunk(pb.ChunkIndex{Lon: int64(validLon), Lat: int64(lat), Alt: int64(alt)}, async)
+	}
+	center.Alt = 0
+
+	// Step a chunk at a time across the surface, which carries over the edges of cube-sphere faces
+	for dLon := -player.renderDistance; dLon <= player.renderDistance; dLon++ {
+		for dLat := -player.renderDistance; dLat <= player.renderDistance; dLat++ {
+			column, ok := planet.NeighborCellIndex(center, int64(dLon)*planet.ChunkSize, int64(dLat)*planet.ChunkSize, 0)
+			if !ok {
+				continue
+			}
+			ind := planet.CellIndexToChunkIndex(column)
+			for _, alt := range alts {
+				planet.GetChunk(pb.ChunkIndex{Lon: ind.Lon, Lat: ind.Lat, Alt: alt}, async)
 			}
 		}
 	}
 }
 
-// UpdatePosition updates the player position
-func (player *Player) UpdatePosition(h float32) {
+// InSpace returns whether the player is above the cells of every planet, moving in the universe frame
+func (player *Player) InSpace() bool {
+	return player.inSpace
+}
+
+// UpdatePosition updates the player position given the universe time in seconds
+func (player *Player) UpdatePosition(h float32, time float64) {
 	planet := player.Planet
-	player.LoadNearbyChunks(true)
+	if !player.inSpace || player.Location().Len() < 2*float32(planet.Spec.Radius) {
+		player.LoadNearbyChunks(true)
+	}
 	if h > 0.05 {
 		h = 0.05
 	}
 
-	up := player.Location().Normalize()
+	up := planet.Up(player.Location())
 	right := player.lookHeading.Cross(up)
-	if player.MovementMode == Normal {
-		feet := player.Location().Sub(up.Mul(float32(player.height)))
-		feetCell := planet.CartesianToCell(feet)
-		falling := feetCell == nil || feetCell.Material == pb.Material_AIR
-		if falling {
-			player.FallVel -= 20 * h
-		} else if player.HoldingJump && !player.inJump {
-			player.FallVel = 7
+	if player.inSpace {
+		player.updateSpacePosition(h, time)
+		planet = player.Planet
+	} else if player.MovementMode == Normal {
+		if player.onGround && player.HoldingJump && !player.inJump {
+			player.FallVel = player.JumpVel
 			player.inJump = true
-		} else {
-			player.FallVel = 0
+		} else if player.onGround {
 			player.inJump = false
 		}
+		player.FallVel -= float32(planet.SurfaceGravity()) * h
+		player.FallVel -= player.FallVel * float32(planet.Spec.AtmosphereDensity*atmosphereDrag) * h
 
 		playerVel := mgl32.Vec3{}
 		playerVel = playerVel.Add(up.Mul(player.FallVel))
 		playerVel = playerVel.Add(player.lookHeading.Mul((player.ForwardVel - player.BackVel)))
 		playerVel = playerVel.Add(right.Mul((player.RightVel - player.LeftVel)))
 
-		player.SetLocation(player.Location().Add(playerVel.Mul(h)))
-		for height := planet.AltDelta / 2; height < player.height; height += planet.AltDelta {
-			player.collide(planet, float32(height), pb.CellLoc{Lon: 0, Lat: 0, Alt: -1})
-			player.collide(planet, float32(height), pb.CellLoc{Lon: 1, Lat: 0, Alt: 0})
-			player.collide(planet, float32(height), pb.CellLoc{Lon: -1, Lat: 0, Alt: 0})
-			player.collide(planet, float32(height), pb.CellLoc{Lon: 0, Lat: 1, Alt: 0})
-			player.collide(planet, float32(height), pb.CellLoc{Lon: 0, Lat: -1, Alt: 0})
+		grounded, ceiling := player.move(planet, playerVel.Mul(h), player.onGround)
+		if (grounded && player.FallVel < 0) || (ceiling && player.FallVel > 0) {
+			player.FallVel = 0
+			playerVel = ProjectToPlane(playerVel, up)
 		}
+		player.onGround = grounded
+		player.vel = playerVel
 	} else if player.MovementMode == Flying {
 		LookDir := player.LookDir()
+		player.vel = up.Mul(player.UpVel - player.DownVel).Add(LookDir.Mul(player.ForwardVel - player.BackVel)).Add(right.Mul(player.RightVel - player.LeftVel))
 		player.SetLocation(player.Location().Add(up.Mul((player.UpVel - player.DownVel) * h)))
 		player.SetLocation(player.Location().Add(LookDir.Mul((player.ForwardVel - player.BackVel) * h)))
 		player.SetLocation(player.Location().Add(right.Mul((player.RightVel - player.LeftVel) * h)))
 	}
 
+	// Falling off the edge of an open world, as far below its cells as they are high, respawns the player
+	if !player.inSpace && !planet.Topology.Closed() && planet.CartesianToCellLoc(player.loc).Alt < -float64(planet.Spec.AltCells) {
+		player.Spawn()
+	}
+
+	// Leaving the top of a closed planet's cells puts the player in space
+	if !player.inSpace && player.Universe != nil && planet.Topology.Closed() && planet.CartesianToCellLoc(player.loc).Alt > float64(planet.Spec.AltCells) {
+		player.spaceLoc, player.spaceVel = planet.FrameToUniverse(player.loc, player.vel, time)
+		player.inSpace = true
+		player.FallVel = 0
+	}
+	player.time = time
+
 	// Update focused cell
-	increment := player.LookDir().Mul(0.05)
-	pos := player.Location()
 	player.FocusCellIndex = pb.CellIndex{Lat: 0, Lon: 0, Alt: 0}
-	for i := 0; i < 100; i++ {
-		pos = pos.Add(increment)
-		cell := planet.CartesianToCell(pos)
-		if cell != nil && cell.Material != pb.Material_AIR {
-			cellIndex := planet.CartesianToCellIndex(pos)
-			player.FocusCellIndex = cellIndex
-			break
-		}
+	if hit, ok := planet.Raycast(player.Location(), player.LookDir(), player.Reach); ok {
+		player.FocusCellIndex = hit.Index
 	}
 }
 
-func (player *Player) collide(p *Planet, height float32, d pb.CellLoc) {
-	up := player.Location().Normalize()
-	pos := player.Location().Sub(up.Mul(float32(player.height) - height))
-	l := p.CartesianToCellLoc(pos)
-	c := p.CellLocToNearestCellCenter(l)
-	adjCell := p.CellLocToCell(pb.CellLoc{
-		Lon: c.Lon + d.Lon,
-		Lat: c.Lat + d.Lat,
-		Alt: c.Alt + d.Alt,
-	})
-	if adjCell != nil && adjCell.Material != pb.Material_AIR {
-		if d.Alt != 0 {
-			nLoc := p.CellLocToCartesian(pb.CellLoc{
-				Lon: c.Lon + d.Lon/2,
-				Lat: c.Lat + d.Lat/2,
-				Alt: c.Alt + d.Alt/2,
-			})
-			distToPlane := up.Dot(pos.Sub(nLoc))
-			if distToPlane < 0 {
-				move := -distToPlane
-				player.SetLocation(player.Location().Add(up.Mul(move)))
-			}
-		} else {
-			nLoc := p.CellLocToCartesian(pb.CellLoc{
-				Lon: c.Lon + d.Lon/2,
-				Lat: c.Lat + d.Lat/2,
-				Alt: c.Alt + d.Alt/2,
-			})
-			aLoc := p.CellLocToCartesian(pb.CellLoc{
-				Lon: c.Lon + d.Lon,
-				Lat: c.Lat + d.Lat,
-				Alt: c.Alt + d.Alt,
-			})
-			cNorm := nLoc.Sub(aLoc).Normalize()
-			cNorm = cNorm.Sub(Project(cNorm, up)).Normalize()
-			distToPlane := cNorm.Dot(pos.Sub(nLoc))
-			if distToPlane < float32(player.radius) {
-				move := float32(player.radius) - distToPlane
-				player.SetLocation(player.Location().Add(cNorm.Mul(move)))
-			}
-		}
+// updateSpacePosition moves the player through space under gravity and their own thrust.
+// The position is kept relative to the planet whose sphere of influence the player is in,
+// switching planets as the player crosses into another sphere of influence.
+func (player *Player) updateSpacePosition(h float32, time float64) {
+	u := player.Universe
+	planet := player.Planet
+
+	// Hold the look direction still in the universe while the planet turns beneath the player
+	heading := PlanetRotation(&planet.Spec, player.time).Mul3x1(player.lookHeading)
+
+	up := planet.Up(player.Location())
+	right := player.lookHeading.Cross(up)
+	thrust := player.LookDir().Mul(player.ForwardVel - player.BackVel)
+	thrust = thrust.Add(right.Mul(player.RightVel - player.LeftVel))
+	thrust = thrust.Add(up.Mul(player.UpVel - player.DownVel))
+	thrust = PlanetRotation(&planet.Spec, time).Mul3x1(thrust).Mul(spaceThrust)
+
+	acc := u.Gravity(planet, player.spaceLoc, time).Add(thrust)
+	player.spaceVel = player.spaceVel.Add(acc.Mul(h))
+	player.spaceLoc = player.spaceLoc.Add(player.spaceVel.Mul(h))
+
+	pos := u.PlanetLocation(planet.Spec.Id, time).Add(player.spaceLoc)
+	if dominant := u.DominantPlanet(pos, time); dominant != nil && dominant != planet {
+		player.spaceLoc = pos.Sub(u.PlanetLocation(dominant.Spec.Id, time))
+		player.spaceVel = player.spaceVel.Add(u.PlanetVelocity(planet.Spec.Id, time)).Sub(u.PlanetVelocity(dominant.Spec.Id, time))
+		player.Planet = dominant
+		planet = dominant
+	}
+
+	loc, vel := planet.UniverseToFrame(player.spaceLoc, player.spaceVel, time)
+	player.SetLocation(loc)
+	player.lookHeading = PlanetRotation(&planet.Spec, time).Transpose().Mul3x1(heading)
+
+	// Dropping into the top of the planet's cells returns the player to the planet's rotating frame
+	if planet.CartesianToCellLoc(loc).Alt <= float64(planet.Spec.AltCells) {
+		player.inSpace = false
+		player.vel = vel
+		player.FallVel = vel.Dot(planet.Up(loc))
 	}
 }
@@ -0,0 +1,181 @@
+package common
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// Ranges used when generating a procedural planetary system
+const (
+	minProceduralPlanets = 3
+	maxProceduralPlanets = 7
+	maxProceduralMoons   = 3
+
+	// Orbit periods grow with the 3/2 power of orbit distance, as in Kepler's third law
+	planetPeriodFactor = 0.2
+	moonPeriodFactor   = 0.09
+
+	// Gap left between the orbits of neighboring bodies beyond their own extents
+	orbitGap = 40.0
+
+	maxPlanetEccentricity = 0.12
+	maxMoonEccentricity   = 0.06
+	maxInclination        = 6.0
+	maxAxialTilt          = 35.0
+)
+
+var (
+	planetGenerators = []string{"biomes", "biomes", "biomes", "bumpy", "sphere", "caves", "moon"}
+	moonGenerators   = []string{"moon", "moon", "rocks", "sphere"}
+	nameSyllables    = []string{
+		"ka", "ro", "ve", "lu", "tha", "an", "mi", "dor", "xa", "zen", "qui", "el",
+		"or", "is", "tan", "bri", "sol", "vy", "ne", "gar", "pe", "ul", "rha", "os",
+	}
+	romanNumerals = []string{"I", "II", "III", "IV", "V"}
+)
+
+// proceduralSystem generates a sun with a varied set of planets and moons from a seed.
+// The spawn planet always has id 0 and uses the biomes generator.
+func proceduralSystem(seed int64) []*pb.PlanetSpec {
+	rng := rand.New(rand.NewSource(seed))
+
+	sunRadius := float64(DefaultChunkSize * (4 + rng.Intn(3)))
+	sun := &pb.PlanetSpec{
+		Id:              1,
+		Name:            planetName(rng),
+		GeneratorType:   "sun",
+		Radius:          sunRadius,
+		AltCells:        int64(sunRadius),
+		OrbitPlanet:     1,
+		RotationSeconds: 1e10,
+		Seed:            rng.Int63(),
+		SurfaceGravity:  2 * defaultGravity,
+	}
+	specs := []*pb.PlanetSpec{sun}
+
+	numPlanets := minProceduralPlanets + rng.Intn(maxProceduralPlanets-minProceduralPlanets+1)
+	spawn := rng.Intn(numPlanets)
+	nextID := int64(2)
+	prevApoapsis := 2 * sunRadius
+	prevReach := sunRadius
+	for i := 0; i < numPlanets; i++ {
+		planet := &pb.PlanetSpec{
+			Name:          planetName(rng),
+			GeneratorType: planetGenerators[rng.Intn(len(planetGenerators))],
+			Radius:        float64(DefaultChunkSize * (2 + rng.Intn(5))),
+			OrbitPlanet:   sun.Id,
+			Seed:          rng.Int63(),
+		}
+		if i == spawn {
+			planet.Id = 0
+			planet.GeneratorType = "biomes"
+			planet.Radius = 64
+		} else {
+			planet.Id = nextID
+			nextID++
+		}
+		planet.AltCells = int64(planet.Radius)
+		planet.RotationSeconds = rotationSeconds(rng)
+		planet.AxialTilt = maxAxialTilt * rng.Float64()
+		planet.TiltDirection = 360 * rng.Float64()
+		planet.SurfaceGravity = surfaceGravity(rng, planet.Radius)
+		planet.AtmosphereDensity = atmosphereDensity(rng, planet.GeneratorType)
+
+		// Moons orbit outward from the planet, each clear of the last
+		moons := []*pb.PlanetSpec{}
+		reach := planet.Radius
+		numMoons := rng.Intn(Min(maxProceduralMoons, int(planet.Radius)/DefaultChunkSize) + 1)
+		for m := 0; m < numMoons; m++ {
+			radius := float64(DefaultChunkSize * (1 + rng.Intn(Max(1, int(planet.Radius)/DefaultChunkSize/2))))
+			ecc := maxMoonEccentricity * rng.Float64()
+			moonDistance := (reach + radius + orbitGap/2 + rng.Float64()*orbitGap) / (1 - ecc)
+			moon := &pb.PlanetSpec{
+				Id:              nextID,
+				Name:            planet.Name + " " + romanNumerals[m],
+				GeneratorType:   moonGenerators[rng.Intn(len(moonGenerators))],
+				Radius:          radius,
+				AltCells:        int64(radius),
+				OrbitPlanet:     planet.Id,
+				OrbitDistance:   moonDistance,
+				OrbitSeconds:    orbitSeconds(rng, moonPeriodFactor, moonDistance),
+				RotationSeconds: rotationSeconds(rng),
+				Seed:            rng.Int63(),
+				SurfaceGravity:  surfaceGravity(rng, radius),
+			}
+			moon.AtmosphereDensity = atmosphereDensity(rng, moon.GeneratorType)
+
+			// The smallest moons use smaller chunks, which fit more closely around them
+			if radius <= DefaultChunkSize {
+				moon.ChunkSize = DefaultChunkSize / 2
+			}
+			setOrbitElements(rng, moon, ecc)
+			moons = append(moons, moon)
+			nextID++
+			reach = moonDistance*(1+ecc) + radius
+		}
+
+		// Space planets out roughly geometrically, keeping the nearest point of this orbit
+		// clear of the furthest point of the last one, moons included
+		ecc := maxPlanetEccentricity * rng.Float64()
+		distance := math.Max(prevApoapsis*(1.4+0.5*rng.Float64()), (prevApoapsis+prevReach+reach+orbitGap)/(1-ecc))
+		planet.OrbitDistance = distance
+		planet.OrbitSeconds = orbitSeconds(rng, planetPeriodFactor, distance)
+		setOrbitElements(rng, planet, ecc)
+		prevApoapsis = distance * (1 + ecc)
+		prevReach = reach
+
+		specs = append(specs, planet)
+		specs = append(specs, moons...)
+	}
+	return specs
+}
+
+// setOrbitElements gives an orbit an eccentricity and a random orientation and starting phase
+func setOrbitElements(rng *rand.Rand, spec *pb.PlanetSpec, eccentricity float64) {
+	spec.Eccentricity = eccentricity
+	spec.Inclination = maxInclination * rng.Float64()
+	spec.AscendingNode = 360 * rng.Float64()
+	spec.OrbitPhase = 360 * rng.Float64()
+}
+
+// orbitSeconds returns an orbit period for a distance, with a little variation
+func orbitSeconds(rng *rand.Rand, factor, distance float64) float64 {
+	return factor * math.Pow(distance, 1.5) * (0.9 + 0.2*rng.Float64())
+}
+
+// surfaceGravity returns a surface gravity that grows with planet radius, with some variation in density
+func surfaceGravity(rng *rand.Rand, radius float64) float64 {
+	return defaultGravity * radius / 64 * (0.8 + 0.4*rng.Float64())
+}
+
+// atmosphereDensity returns an atmosphere density suited to a generator type
+func atmosphereDensity(rng *rand.Rand, generatorType string) float64 {
+	switch generatorType {
+	case "biomes":
+		return 0.8 + 0.5*rng.Float64()
+	case "moon", "rocks":
+		return 0
+	}
+	return 1.5 * rng.Float64()
+}
+
+// rotationSeconds returns a rotation period, occasionally retrograde
+func rotationSeconds(rng *rand.Rand) float64 {
+	seconds := 60 + 240*rng.Float64()
+	if rng.Float64() < 0.15 {
+		return -seconds
+	}
+	return seconds
+}
+
+// planetName returns a pronounceable name made of a few random syllables
+func planetName(rng *rand.Rand) string {
+	name := ""
+	for i := 2 + rng.Intn(2); i > 0; i-- {
+		name += nameSyllables[rng.Intn(len(nameSyllables))]
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,187 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// CellFace identifies a face of a cell
+type CellFace int
+
+// Cell faces, named by the cell index direction they face
+const (
+	NoFace CellFace = iota
+	LonMinFace
+	LonMaxFace
+	LatMinFace
+	LatMaxFace
+	AltMinFace
+	AltMaxFace
+)
+
+const (
+	// maxRaycastSteps bounds the number of cells a single raycast may visit
+	maxRaycastSteps = 10000
+
+	// raycastEpsilon keeps a raycast from finding the face it just entered through again
+	raycastEpsilon = 1e-6
+)
+
+// RaycastHit describes the first solid cell along a ray
+type RaycastHit struct {
+	Cell        *pb.Cell
+	Index       pb.CellIndex
+	Face        CellFace
+	Previous    pb.CellIndex
+	HasPrevious bool
+	Distance    float32
+}
+
+// cellBounds returns the cell location bounds of the cell containing a cell index.
+// Cells in reduced resolution chunks span several longitude or latitude indices.
+func (p *Planet) cellBounds(ind pb.CellIndex) (lonMin, lonMax, latMin, latMax, altMin, altMax float64) {
+	chunk := p.CellIndexToChunkIndex(ind)
+	lonCells, latCells := p.LonLatCellsInChunkIndex(chunk)
+	lonWidth := p.ChunkSize / int64(lonCells)
+	latWidth := p.ChunkSize / int64(latCells)
+	lonStart := chunk.Lon*p.ChunkSize + (ind.Lon-chunk.Lon*p.ChunkSize)/lonWidth*lonWidth
+	latStart := chunk.Lat*p.ChunkSize + (ind.Lat-chunk.Lat*p.ChunkSize)/latWidth*latWidth
+	return float64(lonStart) - 0.5, float64(lonStart+lonWidth) - 0.5,
+		float64(latStart) - 0.5, float64(latStart+latWidth) - 0.5,
+		float64(ind.Alt) - 0.5, float64(ind.Alt) + 0.5
+}
+
+// Raycast walks every cell crossed by a ray from origin along dir, up to maxDist, and returns the first
+// cell that is not air, the face of that cell the ray entered through, and the empty cell visited before it.
+// Positions are in planet coordinates.
+func (p *Planet) Raycast(origin, dir mgl32.Vec3, maxDist float32) (RaycastHit, bool) {
+	o := [3]float64{float64(origin[0]), float64(origin[1]), float64(origin[2])}
+	d := dir.Normalize()
+	v := [3]float64{float64(d[0]), float64(d[1]), float64(d[2])}
+	at := func(t float64) mgl32.Vec3 {
+		return mgl32.Vec3{float32(o[0] + t*v[0]), float32(o[1] + t*v[1]), float32(o[2] + t*v[2])}
+	}
+
+	ind := p.CartesianToCellIndex(origin)
+	face := NoFace
+	prev := pb.CellIndex{}
+	hasPrev := false
+	t := 0.0
+	for step := 0; step < maxRaycastSteps && t <= float64(maxDist); step++ {
+		cell := p.CellIndexToCell(ind)
+		if cell != nil && cell.Material != pb.Material_AIR {
+			return RaycastHit{Cell: cell, Index: ind, Face: face, Previous: prev, HasPrevious: hasPrev, Distance: float32(t)}, true
+		}
+
+		// Find where the ray leaves the current cell, and step into the cell across that face
+		exitT, exitFace := p.Topology.cellRegion(ind).exit(o, v, t)
+		if exitFace == NoFace {
+			break
+		}
+		next, entry, ok := p.Topology.stepAcross(ind, exitFace, at(exitT))
+		if !ok {
+			break
+		}
+		prev, hasPrev = ind, true
+		ind, face = next, entry
+		t = exitT
+	}
+	return RaycastHit{}, false
+}
+
+// rayExit tracks the nearest face a ray leaves a cell through
+type rayExit struct {
+	after float64
+	t     float64
+	face  CellFace
+}
+
+func newRayExit(after float64) rayExit {
+	return rayExit{after: after, t: math.Inf(1), face: NoFace}
+}
+
+// consider records a face met at a distance along the ray if it is the nearest yet past the start
+func (e *rayExit) consider(t float64, face CellFace) {
+	if t > e.after+raycastEpsilon && t < e.t {
+		e.t, e.face = t, face
+	}
+}
+
+// sphereIntersections returns the distances along a ray with unit direction v at which it meets a sphere of radius r about the origin
+func sphereIntersections(o, v [3]float64, r float64) []float64 {
+	b := o[0]*v[0] + o[1]*v[1] + o[2]*v[2]
+	c := o[0]*o[0] + o[1]*o[1] + o[2]*o[2] - r*r
+	disc := b*b - c
+	if disc < 0 {
+		return nil
+	}
+	s := math.Sqrt(disc)
+	return []float64{-b - s, -b + s}
+}
+
+// facePlaneIntersection returns the distance along a ray at which it meets the half plane through the planet center
+// holding the points at tangent-plane coordinate x along an axis of a cube face with normal n, on the side of the face
+func facePlaneIntersection(o, v, axis, n [3]float64, x float64) (float64, bool) {
+	m := [3]float64{axis[0] - x*n[0], axis[1] - x*n[1], axis[2] - x*n[2]}
+	denom := dot3(m, v)
+	if denom == 0 {
+		return 0, false
+	}
+	t := -dot3(m, o) / denom
+	pt := [3]float64{o[0] + t*v[0], o[1] + t*v[1], o[2] + t*v[2]}
+	if dot3(pt, n) <= 0 {
+		return 0, false
+	}
+	return t, true
+}
+
+// halfPlaneIntersection returns the distance along a ray at which it meets the half plane
+// bounded by the Z axis at azimuth phi
+func halfPlaneIntersection(o, v [3]float64, phi float64) (float64, bool) {
+	nx, ny := -math.Sin(phi), math.Cos(phi)
+	denom := nx*v[0] + ny*v[1]
+	if denom == 0 {
+		return 0, false
+	}
+	t := -(nx*o[0] + ny*o[1]) / denom
+	x, y := o[0]+t*v[0], o[1]+t*v[1]
+	if x*math.Cos(phi)+y*math.Sin(phi) < 0 {
+		return 0, false
+	}
+	return t, true
+}
+
+// coneIntersections returns the distances along a ray at which it meets the cone of points
+// at polar angle theta from the Z axis
+func coneIntersections(o, v [3]float64, theta float64) []float64 {
+	c := math.Cos(theta)
+	c2 := c * c
+	a := v[2]*v[2] - c2
+	b := 2 * (o[2]*v[2] - c2*(o[0]*v[0]+o[1]*v[1]+o[2]*v[2]))
+	k := o[2]*o[2] - c2*(o[0]*o[0]+o[1]*o[1]+o[2]*o[2])
+	roots := []float64{}
+	if math.Abs(a) < 1e-12 {
+		if b != 0 {
+			roots = append(roots, -k/b)
+		}
+	} else {
+		disc := b*b - 4*a*k
+		if disc < 0 {
+			return nil
+		}
+		s := math.Sqrt(disc)
+		roots = append(roots, (-b-s)/(2*a), (-b+s)/(2*a))
+	}
+
+	// Squaring the cone equation also admits the mirrored cone on the other side of the XY plane
+	hits := []float64{}
+	for _, t := range roots {
+		z := o[2] + t*v[2]
+		if (z >= 0) == (c >= 0) || math.Abs(z) < 1e-9 {
+			hits = append(hits, t)
+		}
+	}
+	return hits
+}
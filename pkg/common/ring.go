@@ -0,0 +1,241 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// ringTopology lays cells out on the inside of a cylinder about the planet's Z axis, like a ringworld.
+// The ground sits on the hull at the planet radius and up points in toward the axis.
+// Longitude runs around the ring and wraps, while latitude runs across its width along Z and does not.
+type ringTopology struct {
+	p *Planet
+}
+
+func newRingTopology(p *Planet) Topology {
+	// Cells are a unit wide halfway up from the hull
+	p.LonCells = int64(2*math.Pi*(p.Spec.Radius-float64(p.Spec.AltCells)*p.AltDelta/2)+0.5) / p.ChunkSize * p.ChunkSize
+	p.LatCells = int64(math.Max(float64(p.ChunkSize), float64(int64(p.Spec.Radius/2)/p.ChunkSize*p.ChunkSize)))
+	return &ringTopology{p: p}
+}
+
+// wrap brings a longitude cell index back into the range of longitude cells
+func (r *ringTopology) wrap(l pb.CellLoc) pb.CellLoc {
+	p := r.p
+	if l.Lon < 0 {
+		l.Lon += float64(p.LonCells)
+	}
+	for l.Lon >= float64(p.LonCells) {
+		l.Lon -= float64(p.LonCells)
+	}
+	return l
+}
+
+// zOfLat converts a floating-point latitude cell index to the distance along Z from the middle of the ring
+func (r *ringTopology) zOfLat(lat float64) float64 {
+	return lat - float64(r.p.LatCells-1)/2
+}
+
+// rhoOfAlt converts a floating-point altitude cell index to the distance from the Z axis
+func (r *ringTopology) rhoOfAlt(alt float64) float64 {
+	return r.p.Spec.Radius - alt*r.p.AltDelta
+}
+
+func (r *ringTopology) CellLocToCartesian(l pb.CellLoc) mgl32.Vec3 {
+	p := r.p
+	l = r.wrap(l)
+	phi := 2 * math.Pi * l.Lon / float64(p.LonCells)
+	rho := r.rhoOfAlt(l.Alt)
+	return mgl32.Vec3{float32(rho * math.Cos(phi)), float32(rho * math.Sin(phi)), float32(r.zOfLat(l.Lat))}
+}
+
+func (r *ringTopology) CellVertexToCartesian(ind pb.CellIndex, l pb.CellLoc) mgl32.Vec3 {
+	return r.CellLocToCartesian(l)
+}
+
+func (r *ringTopology) CartesianToCellLoc(cart mgl32.Vec3) pb.CellLoc {
+	p := r.p
+	x, y, z := float64(cart[0]), float64(cart[1]), float64(cart[2])
+	phi := math.Atan2(y, x)
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+	return pb.CellLoc{
+		Lon: phi * float64(p.LonCells) / (2 * math.Pi),
+		Lat: z + float64(p.LatCells-1)/2,
+		Alt: (p.Spec.Radius - math.Hypot(x, y)) / p.AltDelta,
+	}
+}
+
+func (r *ringTopology) NearestCellCenter(l pb.CellLoc) pb.CellLoc {
+	l = r.wrap(l)
+	return r.wrap(pb.CellLoc{
+		Lon: math.Floor(l.Lon + 0.5),
+		Lat: math.Floor(l.Lat + 0.5),
+		Alt: math.Floor(l.Alt + 0.5),
+	})
+}
+
+func (r *ringTopology) NeighborCellIndex(ind pb.CellIndex, dLon, dLat, dAlt int64) (pb.CellIndex, bool) {
+	return r.p.gridNeighborCellIndex(ind, dLon, dLat, dAlt, true)
+}
+
+// LonLatCellsInChunkIndex keeps every chunk at full resolution, since the cells of a ring never crowd together
+func (r *ringTopology) LonLatCellsInChunkIndex(ind pb.ChunkIndex) (lonCells, latCells int) {
+	return int(r.p.ChunkSize), int(r.p.ChunkSize)
+}
+
+// Up points toward the Z axis
+func (r *ringTopology) Up(cart mgl32.Vec3) mgl32.Vec3 {
+	out := mgl32.Vec3{cart[0], cart[1], 0}
+	if out.Len() == 0 {
+		return mgl32.Vec3{-1, 0, 0}
+	}
+	return out.Normalize().Mul(-1)
+}
+
+// Latitude runs from one rim of the ring to the other
+func (r *ringTopology) Latitude(l pb.CellLoc) float64 {
+	return r.p.gridLatitude(l)
+}
+
+// SpawnLoc is across the middle of the ring at zero longitude, on the planet's X axis
+func (r *ringTopology) SpawnLoc() pb.CellLoc {
+	return pb.CellLoc{Lon: 0, Lat: float64(r.p.LatCells-1) / 2}
+}
+
+func (r *ringTopology) Closed() bool {
+	return false
+}
+
+func (r *ringTopology) geometrySampleLoc(lon, lat, lonSamples, latSamples int) pb.CellLoc {
+	return r.p.gridGeometrySampleLoc(lon, lat, lonSamples, latSamples)
+}
+
+// ringCell is the region of space covered by a cell of a ring, bounded by two cylinders about the Z axis,
+// two half planes and two planes across the Z axis. Angles are in radians.
+type ringCell struct {
+	rhoMin, rhoMax float64
+	phiMin, phiMax float64
+	zMin, zMax     float64
+}
+
+func (r *ringTopology) cellRegion(ind pb.CellIndex) cellRegion {
+	p := r.p
+	lonMin, lonMax, latMin, latMax, altMin, altMax := p.cellBounds(ind)
+	return ringCell{
+		rhoMin: math.Max(0, r.rhoOfAlt(altMax)),
+		rhoMax: r.rhoOfAlt(altMin),
+		phiMin: 2 * math.Pi * lonMin / float64(p.LonCells),
+		phiMax: 2 * math.Pi * lonMax / float64(p.LonCells),
+		zMin:   r.zOfLat(latMin),
+		zMax:   r.zOfLat(latMax),
+	}
+}
+
+func (c ringCell) push(center mgl32.Vec3, radius float64) (mgl32.Vec3, bool) {
+	x, y, z := float64(center[0]), float64(center[1]), float64(center[2])
+	rho := math.Hypot(x, y)
+	if rho == 0 {
+		return mgl32.Vec3{}, false
+	}
+	phi := math.Atan2(y, x)
+	mid := (c.phiMin + c.phiMax) / 2
+	half := (c.phiMax - c.phiMin) / 2
+	dphi := math.Remainder(phi-mid, 2*math.Pi)
+
+	inside := rho >= c.rhoMin && rho <= c.rhoMax && z >= c.zMin && z <= c.zMax && math.Abs(dphi) <= half
+	if !inside {
+		// Clamp the sphere center into the cell to find the nearest point of the cell
+		rc := math.Max(c.rhoMin, math.Min(c.rhoMax, rho))
+		zc := math.Max(c.zMin, math.Min(c.zMax, z))
+		pc := mid + math.Max(-half, math.Min(half, dphi))
+		nearest := mgl32.Vec3{float32(rc * math.Cos(pc)), float32(rc * math.Sin(pc)), float32(zc)}
+		return pushFromNearest(center, nearest, radius)
+	}
+
+	// The center is inside the cell, so leave through the nearest face
+	out := mgl32.Vec3{float32(math.Cos(phi)), float32(math.Sin(phi)), 0}
+	east := mgl32.Vec3{float32(-math.Sin(phi)), float32(math.Cos(phi)), 0}
+	north := mgl32.Vec3{0, 0, 1}
+	inward := rho - c.rhoMin
+	if c.rhoMin == 0 {
+		inward = math.Inf(1)
+	}
+	dir, dist := out, c.rhoMax-rho
+	faces := []struct {
+		dir  mgl32.Vec3
+		dist float64
+	}{
+		{out.Mul(-1), inward},
+		{north.Mul(-1), z - c.zMin},
+		{north, c.zMax - z},
+		{east.Mul(-1), rho * (dphi + half)},
+		{east, rho * (half - dphi)},
+	}
+	for _, f := range faces {
+		if f.dist < dist {
+			dir, dist = f.dir, f.dist
+		}
+	}
+	return dir.Mul(float32(dist + radius)), true
+}
+
+func (c ringCell) exit(o, v [3]float64, after float64) (float64, CellFace) {
+	e := newRayExit(after)
+	for _, b := range []struct {
+		rho  float64
+		face CellFace
+	}{{c.rhoMin, AltMaxFace}, {c.rhoMax, AltMinFace}} {
+		if b.rho <= 0 {
+			continue
+		}
+		for _, t := range cylinderIntersections(o, v, b.rho) {
+			e.consider(t, b.face)
+		}
+	}
+	for _, b := range []struct {
+		phi  float64
+		face CellFace
+	}{{c.phiMin, LonMinFace}, {c.phiMax, LonMaxFace}} {
+		if t, ok := halfPlaneIntersection(o, v, b.phi); ok {
+			e.consider(t, b.face)
+		}
+	}
+	if v[2] != 0 {
+		e.consider((c.zMin-o[2])/v[2], LatMinFace)
+		e.consider((c.zMax-o[2])/v[2], LatMaxFace)
+	}
+	return e.t, e.face
+}
+
+func (r *ringTopology) stepAcross(ind pb.CellIndex, exit CellFace, pt mgl32.Vec3) (pb.CellIndex, CellFace, bool) {
+	return r.p.gridStepAcross(ind, exit)
+}
+
+func (r *ringTopology) solidCellsNear(center mgl32.Vec3, reach float64) []cellRegion {
+	p := r.p
+
+	// Cells are narrowest around the ring closest to the axis
+	rho := math.Hypot(float64(center[0]), float64(center[1]))
+	width := math.Min(1, math.Max(rho-reach, p.AltDelta)*2*math.Pi/float64(p.LonCells))
+	return p.gridSolidCellsNear(center, reach, width)
+}
+
+// cylinderIntersections returns the distances along a ray with unit direction v at which it meets a cylinder of radius r about the Z axis
+func cylinderIntersections(o, v [3]float64, r float64) []float64 {
+	a := v[0]*v[0] + v[1]*v[1]
+	if a == 0 {
+		return nil
+	}
+	b := o[0]*v[0] + o[1]*v[1]
+	c := o[0]*o[0] + o[1]*o[1] - r*r
+	disc := b*b - a*c
+	if disc < 0 {
+		return nil
+	}
+	s := math.Sqrt(disc)
+	return []float64{(-b - s) / a, (-b + s) / a}
+}
@@ -0,0 +1,67 @@
+package common
+
+import "math"
+
+const (
+	// SimulationStep is the fixed time step in seconds at which players are simulated
+	SimulationStep = 1.0 / 60.0
+
+	// maxSimulationSteps caps the steps run to catch up in one advance, so that after a long stall
+	// the simulation skips ahead instead of falling further behind
+	maxSimulationSteps = 15
+)
+
+// Simulation advances a player in fixed time steps, so that movement is the same at any frame rate.
+// It needs no window, so it runs the same headless as it does in the client.
+type Simulation struct {
+	Player *Player
+	time   float64
+}
+
+// NewSimulation creates a simulation of a player starting at a universe time in seconds
+func NewSimulation(player *Player, time float64) *Simulation {
+	player.prevLoc = player.loc
+	player.renderLoc = player.loc
+	return &Simulation{Player: player, time: time}
+}
+
+// Time returns the universe time in seconds of the last simulated step
+func (s *Simulation) Time() float64 {
+	return s.time
+}
+
+// Step advances the simulation by a single fixed time step
+func (s *Simulation) Step() {
+	player := s.Player
+	planet := player.Planet
+	player.prevLoc = player.loc
+	s.time += SimulationStep
+	player.UpdatePosition(SimulationStep, s.time)
+
+	// Locations on different planets are in different frames, so there is nothing to interpolate between.
+	// Spawn resets the previous location itself, which covers respawning on the same planet.
+	if player.Planet != planet {
+		player.prevLoc = player.loc
+	}
+}
+
+// Advance runs the fixed steps needed to catch up to a universe time in seconds and returns the number of steps run
+func (s *Simulation) Advance(time float64) int {
+	steps := int(math.Floor((time - s.time) / SimulationStep))
+	if steps > maxSimulationSteps {
+		s.time = time - maxSimulationSteps*SimulationStep
+		steps = maxSimulationSteps
+	}
+	for i := 0; i < steps; i++ {
+		s.Step()
+	}
+	return Max(steps, 0)
+}
+
+// Interpolate places the player for rendering at a universe time in seconds,
+// blending the last two steps by how far the time is past the last one
+func (s *Simulation) Interpolate(time float64) {
+	player := s.Player
+	alpha := float32(math.Max(0, math.Min(1, (time-s.time)/SimulationStep)))
+	player.renderLoc = player.prevLoc.Add(player.loc.Sub(player.prevLoc).Mul(alpha))
+}
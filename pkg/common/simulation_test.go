@@ -0,0 +1,121 @@
+package common
+
+import (
+	"math"
+	"testing"
+)
+
+// testSimulation spawns a player on the spawn planet and lets them settle on the ground
+func testSimulation(t *testing.T) *Simulation {
+	player := NewPlayer("test")
+	player.Planet = NewPlanet(nil, NewMemoryChunkStore(), *systems["planet"](1)[0])
+	player.Spawn()
+	s := NewSimulation(player, 0)
+	for i := 0; i < 300; i++ {
+		s.Step()
+	}
+	if !player.onGround {
+		t.Fatal("player did not land after spawning")
+	}
+	return s
+}
+
+// jumpStep is a simulation step of a jump, numbered from when the jump starts
+type jumpStep struct {
+	height   float32
+	onGround bool
+}
+
+func TestSimulationFrameRate(t *testing.T) {
+	const jumpSteps = 180
+
+	// The reference jump runs one step at a time
+	s := testSimulation(t)
+	ground := s.Player.Location().Len()
+	s.Player.HoldingJump = true
+	reference := []jumpStep{}
+	for i := 0; i < jumpSteps; i++ {
+		s.Step()
+		s.Player.HoldingJump = s.Player.HoldingJump && s.Player.onGround
+		reference = append(reference, jumpStep{s.Player.Location().Len() - ground, s.Player.onGround})
+	}
+	apex, landing := float32(0), -1
+	for i, step := range reference {
+		apex = float32(math.Max(float64(apex), float64(step.height)))
+		if landing < 0 && i > 0 && step.onGround {
+			landing = i
+		}
+	}
+	if apex < 1 || landing < 0 {
+		t.Fatalf("reference jump reached %v and landed at step %v", apex, landing)
+	}
+
+	// Advancing to frame times at any rate runs the same steps, so every step a frame lands on matches the reference
+	for _, hz := range []float64{30, 60, 144} {
+		s := testSimulation(t)
+		start := s.Time()
+		s.Player.HoldingJump = true
+		seen := 0
+		for frame := 1; s.Time() < start+(jumpSteps-1)*SimulationStep; frame++ {
+			if s.Advance(start+float64(frame)/hz) == 0 {
+				continue
+			}
+			s.Player.HoldingJump = s.Player.HoldingJump && s.Player.onGround
+			i := int(math.Floor((s.Time()-start)/SimulationStep+0.5)) - 1
+			got := jumpStep{s.Player.Location().Len() - ground, s.Player.onGround}
+			if got != reference[i] {
+				t.Fatalf("%v Hz: step %v is %+v, want %+v", hz, i, got, reference[i])
+			}
+			seen++
+		}
+		if min := int(math.Min(jumpSteps, jumpSteps*hz/60)) - 2; seen < min {
+			t.Errorf("%v Hz: checked %v steps, want at least %v", hz, seen, min)
+		}
+		if got := s.Player.Location().Len() - ground; got != reference[jumpSteps-1].height {
+			t.Errorf("%v Hz: ended at %v, want %v", hz, got, reference[jumpSteps-1].height)
+		}
+	}
+}
+
+func TestSimulationCatchUp(t *testing.T) {
+	s := testSimulation(t)
+	start := s.Time()
+
+	// A long stall skips ahead, only running the capped number of steps to reach the time
+	if steps := s.Advance(start + 10); steps != maxSimulationSteps {
+		t.Errorf("ran %v steps after a stall, want %v", steps, maxSimulationSteps)
+	}
+	if math.Abs(s.Time()-(start+10)) > 1e-9 {
+		t.Errorf("time after a stall is %v, want %v", s.Time(), start+10)
+	}
+	if steps := s.Advance(start + 10); steps != 0 {
+		t.Errorf("ran %v steps to catch up to the same time, want 0", steps)
+	}
+	if steps := s.Advance(s.Time() - 1); steps != 0 {
+		t.Errorf("ran %v steps for an earlier time, want 0", steps)
+	}
+	if steps := s.Advance(s.Time() + 2.5*SimulationStep); steps != 2 {
+		t.Errorf("ran %v steps for two and a half steps, want 2", steps)
+	}
+}
+
+func TestSimulationRespawn(t *testing.T) {
+	s := testSimulation(t)
+	spawn := s.Player.Location()
+
+	// Dying away from the spawn respawns the player on the same planet, which is not blended with where they died
+	planet := s.Player.Planet
+	s.Player.SetLocation(spawn.Mul(1.5))
+	s.Step()
+	s.Player.UpdateHealth(-MaxHealth)
+	if s.Player.Planet != planet {
+		t.Fatal("player changed planets")
+	}
+	if d := s.Player.Location().Sub(spawn).Len(); d > 10 {
+		t.Fatalf("player is %v from the spawn after dying", d)
+	}
+	s.Interpolate(s.Time() + SimulationStep/2)
+	if s.Player.RenderLocation() != s.Player.Location() {
+		t.Errorf("respawn renders at %v, want %v", s.Player.RenderLocation(), s.Player.Location())
+	}
+}
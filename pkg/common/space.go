@@ -0,0 +1,124 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// defaultGravity is the acceleration due to gravity at the nominal surface of a planet without a SurfaceGravity
+	defaultGravity = 20.0
+
+	// frameDelta is the time step in seconds used to differentiate planet motion
+	frameDelta = 0.01
+)
+
+// SurfaceRadius returns the distance from the planet center to its nominal surface, halfway up its cells
+func (p *Planet) SurfaceRadius() float64 {
+	return p.AltMin + float64(p.Spec.AltCells)/2*p.AltDelta
+}
+
+// SurfaceGravity returns the acceleration due to gravity at the nominal surface of the planet
+func (p *Planet) SurfaceGravity() float64 {
+	if p.Spec.SurfaceGravity <= 0 {
+		return defaultGravity
+	}
+	return p.Spec.SurfaceGravity
+}
+
+// GravityParameter returns the product of the gravitational constant and the planet's mass,
+// derived from the gravity at the nominal surface
+func (p *Planet) GravityParameter() float64 {
+	r := p.SurfaceRadius()
+	return p.SurfaceGravity() * r * r
+}
+
+// GravityAt returns the acceleration due to the planet's gravity at an offset from its center
+func (p *Planet) GravityAt(offset mgl32.Vec3) mgl32.Vec3 {
+	if offset.Len() == 0 {
+		return mgl32.Vec3{}
+	}
+	r := math.Max(float64(offset.Len()), p.SurfaceRadius())
+	return offset.Normalize().Mul(float32(-p.GravityParameter() / (r * r)))
+}
+
+// PlanetVelocity returns the velocity of a planet in the universe at a time in seconds
+func (u *Universe) PlanetVelocity(id int64, time float64) mgl32.Vec3 {
+	return u.PlanetLocation(id, time+frameDelta).Sub(u.PlanetLocation(id, time-frameDelta)).Mul(1 / (2 * frameDelta))
+}
+
+// SphereOfInfluence returns the radius around a planet within which its gravity dominates the planet it orbits.
+// It never shrinks below the top of the planet's cells, and never reaches more than halfway to its parent.
+// A planet orbiting itself has an unbounded sphere of influence.
+func (u *Universe) SphereOfInfluence(id int64) float64 {
+	planet := u.PlanetMap[id]
+	parent := u.PlanetMap[planet.Spec.OrbitPlanet]
+	if parent == nil || parent == planet {
+		return math.Inf(1)
+	}
+	soi := planet.Spec.OrbitDistance * math.Pow(planet.GravityParameter()/parent.GravityParameter(), 0.4)
+	return math.Max(math.Min(soi, planet.Spec.OrbitDistance/2), planet.Spec.Radius)
+}
+
+// DominantPlanet returns the planet with the smallest sphere of influence containing a universe position
+func (u *Universe) DominantPlanet(pos mgl32.Vec3, time float64) *Planet {
+	var best *Planet
+	bestSOI := math.Inf(1)
+	for id, planet := range u.PlanetMap {
+		soi := u.SphereOfInfluence(id)
+		if float64(pos.Sub(u.PlanetLocation(id, time)).Len()) > soi {
+			continue
+		}
+		if best == nil || soi < bestSOI || (soi == bestSOI && id < best.Spec.Id) {
+			best, bestSOI = planet, soi
+		}
+	}
+	return best
+}
+
+// Gravity returns the acceleration due to gravity at an offset from a planet, in a frame moving with that planet.
+// The other planets contribute only their tidal pull, the difference between their gravity at the position and at the planet.
+func (u *Universe) Gravity(planet *Planet, offset mgl32.Vec3, time float64) mgl32.Vec3 {
+	center := u.PlanetLocation(planet.Spec.Id, time)
+	pos := center.Add(offset)
+	g := planet.GravityAt(offset)
+	for id, other := range u.PlanetMap {
+		if other == planet {
+			continue
+		}
+		loc := u.PlanetLocation(id, time)
+		g = g.Add(other.GravityAt(pos.Sub(loc))).Sub(other.GravityAt(center.Sub(loc)))
+	}
+	return g
+}
+
+// FrameToUniverse converts a position and velocity in the planet's rotating frame to a universe-aligned
+// position and velocity relative to the planet's center
+func (p *Planet) FrameToUniverse(loc, vel mgl32.Vec3, time float64) (offset, offsetVel mgl32.Vec3) {
+	spec := &p.Spec
+	offset = PlanetRotation(spec, time).Mul3x1(loc)
+	spin := PlanetRotation(spec, time+frameDelta).Mul3x1(loc).Sub(PlanetRotation(spec, time-frameDelta).Mul3x1(loc)).Mul(1 / (2 * frameDelta))
+	offsetVel = PlanetRotation(spec, time).Mul3x1(vel).Add(spin)
+	return
+}
+
+// UniverseToFrame converts a universe-aligned position and velocity relative to the planet's center
+// to a position and velocity in the planet's rotating frame
+func (p *Planet) UniverseToFrame(offset, offsetVel mgl32.Vec3, time float64) (loc, vel mgl32.Vec3) {
+	inv := PlanetRotation(&p.Spec, time).Transpose()
+	loc = inv.Mul3x1(offset)
+	_, spin := p.FrameToUniverse(loc, mgl32.Vec3{}, time)
+	vel = inv.Mul3x1(offsetVel.Sub(spin))
+	return
+}
+
+// NewClientUniverse creates a universe from planets that already exist, such as those a client receives from a server
+func NewClientUniverse(planets []*Planet) *Universe {
+	u := Universe{}
+	u.PlanetMap = make(map[int64]*Planet)
+	for _, planet := range planets {
+		u.PlanetMap[planet.Spec.Id] = planet
+	}
+	return &u
+}
@@ -0,0 +1,327 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// sphereTopology lays cells out on a grid of longitude and latitude about the planet's Z axis,
+// with fewer, wider cells in chunks near the poles and near the core
+type sphereTopology struct {
+	p *Planet
+}
+
+// sphereLonCells returns the number of longitude cells around the equator of a sphere
+func sphereLonCells(radius float64, chunkSize int64) int64 {
+	return int64(2.0*math.Pi*3.0/4.0*(0.5*radius)+0.5) / chunkSize * chunkSize
+}
+
+func newSphereTopology(p *Planet) Topology {
+	p.LonCells = sphereLonCells(p.Spec.Radius, p.ChunkSize)
+	p.LatCells = int64(p.LatMax/90.0*math.Pi*(0.5*p.Spec.Radius)) / p.ChunkSize * p.ChunkSize
+	return &sphereTopology{p: p}
+}
+
+// wrap brings a longitude cell index back into the range of longitude cells
+func (s *sphereTopology) wrap(l pb.CellLoc) pb.CellLoc {
+	p := s.p
+	if l.Lon < 0 {
+		l.Lon += float64(p.LonCells)
+	}
+	for l.Lon >= float64(p.LonCells) {
+		l.Lon -= float64(p.LonCells)
+	}
+	return l
+}
+
+func (s *sphereTopology) CellLocToCartesian(l pb.CellLoc) mgl32.Vec3 {
+	r, theta, phi := s.cellLocToSpherical(s.wrap(l))
+	return mgl32.SphericalToCartesian(r, theta, phi)
+}
+
+func (s *sphereTopology) CellVertexToCartesian(ind pb.CellIndex, l pb.CellLoc) mgl32.Vec3 {
+	return s.CellLocToCartesian(l)
+}
+
+func (s *sphereTopology) CartesianToCellLoc(cart mgl32.Vec3) pb.CellLoc {
+	r, theta, phi := mgl32.CartesianToSpherical(cart)
+	return s.sphericalToCellLoc(r, theta, phi)
+}
+
+func (s *sphereTopology) NearestCellCenter(l pb.CellLoc) pb.CellLoc {
+	l = s.wrap(l)
+	return s.wrap(pb.CellLoc{
+		Lon: math.Floor(l.Lon + 0.5),
+		Lat: math.Floor(l.Lat + 0.5),
+		Alt: math.Floor(l.Alt + 0.5),
+	})
+}
+
+func (s *sphereTopology) NeighborCellIndex(ind pb.CellIndex, dLon, dLat, dAlt int64) (pb.CellIndex, bool) {
+	return s.p.gridNeighborCellIndex(ind, dLon, dLat, dAlt, true)
+}
+
+// LonLatCellsInChunkIndex lowers the longitude cells in chunks near the poles, and both longitude and latitude cells near the core
+func (s *sphereTopology) LonLatCellsInChunkIndex(ind pb.ChunkIndex) (lonCells, latCells int) {
+	p := s.p
+	lonCells = int(p.ChunkSize)
+	latCells = int(p.ChunkSize)
+
+	// If chunk is too close to the poles, lower the longitude cells per chunk
+	theta := (90.0 - float32(p.LatMax) + (float32(ind.Lat)+0.5)*float32(p.ChunkSize)/float32(p.LatCells)) * (2.0 * float32(p.LatMax))
+	if math.Abs(float64(theta-90)) >= 60 {
+		lonCells = halveCells(lonCells)
+	}
+	if math.Abs(float64(theta-90)) >= 80 {
+		lonCells = halveCells(lonCells)
+	}
+
+	return p.reduceNearCore(ind, lonCells, latCells)
+}
+
+func (s *sphereTopology) Up(cart mgl32.Vec3) mgl32.Vec3 {
+	return cart.Normalize()
+}
+
+func (s *sphereTopology) Latitude(l pb.CellLoc) float64 {
+	_, theta, _ := s.cellLocToSpherical(s.wrap(l))
+	return 90 - float64(theta)*180/math.Pi
+}
+
+// SpawnLoc is on the equator at zero longitude, along the planet's X axis
+func (s *sphereTopology) SpawnLoc() pb.CellLoc {
+	return pb.CellLoc{Lon: 0, Lat: float64(s.p.LatCells)/2 - 0.5}
+}
+
+func (s *sphereTopology) Closed() bool {
+	return true
+}
+
+func (s *sphereTopology) geometrySampleLoc(lon, lat, lonSamples, latSamples int) pb.CellLoc {
+	return s.p.gridGeometrySampleLoc(lon, lat, lonSamples, latSamples)
+}
+
+// sphericalToCellLoc converts spherical coordinates to floating-point cell indices
+func (s *sphereTopology) sphericalToCellLoc(r, theta, phi float32) pb.CellLoc {
+	p := s.p
+	alt := (r - float32(p.AltMin)) / float32(p.AltDelta)
+	lat := (180*theta/math.Pi-90+float32(p.LatMax))*float32(p.LatCells)/(2*float32(p.LatMax)) - 0.5
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+	lon := phi * float32(p.LonCells) / (2 * math.Pi)
+	return pb.CellLoc{Lon: float64(lon), Lat: float64(lat), Alt: float64(alt)}
+}
+
+// cellLocToSpherical converts floating-point cell indices to spherical coordinates
+func (s *sphereTopology) cellLocToSpherical(l pb.CellLoc) (r, theta, phi float32) {
+	p := s.p
+	r = float32(l.Alt)*float32(p.AltDelta) + float32(p.AltMin)
+	theta = (math.Pi / 180) * ((90.0 - float32(p.LatMax)) + ((float32(l.Lat)+0.5)/float32(p.LatCells))*(2.0*float32(p.LatMax)))
+	phi = 2 * math.Pi * float32(l.Lon) / float32(p.LonCells)
+	return
+}
+
+// latLocToTheta converts a floating-point latitude cell index to the angle from the planet's Z axis in radians
+func (s *sphereTopology) latLocToTheta(lat float64) float64 {
+	p := s.p
+	return (math.Pi / 180) * ((90.0 - p.LatMax) + ((lat+0.5)/float64(p.LatCells))*(2.0*p.LatMax))
+}
+
+// thetaToLatLoc converts an angle from the planet's Z axis in radians to a floating-point latitude cell index
+func (s *sphereTopology) thetaToLatLoc(theta float64) float64 {
+	p := s.p
+	return (180*theta/math.Pi-90+p.LatMax)*float64(p.LatCells)/(2*p.LatMax) - 0.5
+}
+
+// cellShell is the region of space covered by a cell of a sphere, bounded by two spheres, two cones and two half planes.
+// Angles are in radians, with theta measured from the planet's Z axis.
+type cellShell struct {
+	rMin, rMax         float64
+	thetaMin, thetaMax float64
+	phiMin, phiMax     float64
+}
+
+// cellRegion returns a shell, which may span several indices in reduced resolution chunks
+func (s *sphereTopology) cellRegion(ind pb.CellIndex) cellRegion {
+	p := s.p
+	lonMin, lonMax, latMin, latMax, altMin, altMax := p.cellBounds(ind)
+	return cellShell{
+		rMin:     math.Max(0, altMin*p.AltDelta+p.AltMin),
+		rMax:     altMax*p.AltDelta + p.AltMin,
+		thetaMin: math.Max(0, s.latLocToTheta(latMin)),
+		thetaMax: math.Min(math.Pi, s.latLocToTheta(latMax)),
+		phiMin:   2 * math.Pi * lonMin / float64(p.LonCells),
+		phiMax:   2 * math.Pi * lonMax / float64(p.LonCells),
+	}
+}
+
+func (c cellShell) push(center mgl32.Vec3, radius float64) (mgl32.Vec3, bool) {
+	x, y, z := float64(center[0]), float64(center[1]), float64(center[2])
+	r := math.Sqrt(x*x + y*y + z*z)
+	if r == 0 {
+		return mgl32.Vec3{}, false
+	}
+	theta := math.Acos(math.Max(-1, math.Min(1, z/r)))
+	phi := math.Atan2(y, x)
+	mid := (c.phiMin + c.phiMax) / 2
+	half := (c.phiMax - c.phiMin) / 2
+	dphi := math.Remainder(phi-mid, 2*math.Pi)
+
+	inside := r >= c.rMin && r <= c.rMax && theta >= c.thetaMin && theta <= c.thetaMax && math.Abs(dphi) <= half
+	if !inside {
+		// Clamp the sphere center into the cell to find the nearest point of the cell
+		rc := math.Max(c.rMin, math.Min(c.rMax, r))
+		tc := math.Max(c.thetaMin, math.Min(c.thetaMax, theta))
+		pc := mid + math.Max(-half, math.Min(half, dphi))
+		nearest := mgl32.Vec3{
+			float32(rc * math.Sin(tc) * math.Cos(pc)),
+			float32(rc * math.Sin(tc) * math.Sin(pc)),
+			float32(rc * math.Cos(tc)),
+		}
+		return pushFromNearest(center, nearest, radius)
+	}
+
+	// The center is inside the cell, so leave through the nearest face
+	radial := center.Mul(float32(1 / r))
+	south := mgl32.Vec3{float32(math.Cos(theta) * math.Cos(phi)), float32(math.Cos(theta) * math.Sin(phi)), float32(-math.Sin(theta))}
+	east := mgl32.Vec3{float32(-math.Sin(phi)), float32(math.Cos(phi)), 0}
+	inward := r - c.rMin
+	if c.rMin == 0 {
+		inward = math.Inf(1)
+	}
+	dir, dist := radial, c.rMax-r
+	faces := []struct {
+		dir  mgl32.Vec3
+		dist float64
+	}{
+		{radial.Mul(-1), inward},
+		{south.Mul(-1), r * (theta - c.thetaMin)},
+		{south, r * (c.thetaMax - theta)},
+		{east.Mul(-1), r * math.Sin(theta) * (dphi + half)},
+		{east, r * math.Sin(theta) * (half - dphi)},
+	}
+	for _, f := range faces {
+		if f.dist < dist {
+			dir, dist = f.dir, f.dist
+		}
+	}
+	return dir.Mul(float32(dist + radius)), true
+}
+
+func (c cellShell) exit(o, v [3]float64, after float64) (float64, CellFace) {
+	e := newRayExit(after)
+	for _, b := range []struct {
+		r    float64
+		face CellFace
+	}{{c.rMin, AltMinFace}, {c.rMax, AltMaxFace}} {
+		if b.r <= 0 {
+			continue
+		}
+		for _, t := range sphereIntersections(o, v, b.r) {
+			e.consider(t, b.face)
+		}
+	}
+	for _, b := range []struct {
+		phi  float64
+		face CellFace
+	}{{c.phiMin, LonMinFace}, {c.phiMax, LonMaxFace}} {
+		if t, ok := halfPlaneIntersection(o, v, b.phi); ok {
+			e.consider(t, b.face)
+		}
+	}
+	for _, b := range []struct {
+		theta float64
+		face  CellFace
+	}{{c.thetaMin, LatMinFace}, {c.thetaMax, LatMaxFace}} {
+		if b.theta <= 0 || b.theta >= math.Pi {
+			continue
+		}
+		for _, t := range coneIntersections(o, v, b.theta) {
+			e.consider(t, b.face)
+		}
+	}
+	return e.t, e.face
+}
+
+// stepAcross keeps the other two coordinates of the exit point, so that steps into cells of a different resolution land correctly
+func (s *sphereTopology) stepAcross(ind pb.CellIndex, exit CellFace, pt mgl32.Vec3) (pb.CellIndex, CellFace, bool) {
+	p := s.p
+	lonMin, lonMax, latMin, latMax, altMin, altMax := p.cellBounds(ind)
+	loc := s.CartesianToCellLoc(pt)
+	next := pb.CellIndex{
+		Lon: int64(math.Floor(loc.Lon + 0.5)),
+		Lat: int64(math.Floor(loc.Lat + 0.5)),
+		Alt: int64(math.Floor(loc.Alt + 0.5)),
+	}
+	switch exit {
+	case LonMinFace:
+		next.Lon = int64(lonMin - 0.5)
+	case LonMaxFace:
+		next.Lon = int64(lonMax + 0.5)
+	case LatMinFace:
+		next.Lat = int64(math.Floor(latMin - 0.5))
+	case LatMaxFace:
+		next.Lat = int64(latMax + 0.5)
+	case AltMinFace:
+		next.Alt = int64(math.Floor(altMin - 0.5))
+	case AltMaxFace:
+		next.Alt = int64(altMax + 0.5)
+	}
+	next.Lon = (next.Lon%p.LonCells + p.LonCells) % p.LonCells
+	if next.Lat < 0 || next.Lat >= p.LatCells {
+		// Crossing a pole, where every longitude meets
+		next.Lat = int64(math.Max(0, math.Min(float64(p.LatCells-1), float64(next.Lat))))
+	}
+	return next, oppositeFace(exit), true
+}
+
+// solidCellsNear bounds the cap of directions within reach of the point, which stays small even where cells crowd together at the poles
+func (s *sphereTopology) solidCellsNear(center mgl32.Vec3, reach float64) []cellRegion {
+	p := s.p
+	x, y, z := float64(center[0]), float64(center[1]), float64(center[2])
+	r := math.Sqrt(x*x + y*y + z*z)
+	altLo := int64(math.Floor((r-reach-p.AltMin)/p.AltDelta + 0.5))
+	altHi := int64(math.Floor((r+reach-p.AltMin)/p.AltDelta + 0.5))
+	latLo, latHi := int64(0), p.LatCells-1
+	lonLo, lonHi := int64(0), p.LonCells-1
+	if reach < r {
+		theta := math.Acos(math.Max(-1, math.Min(1, z/r)))
+		spread := math.Asin(reach / r)
+		latLo = int64(math.Floor(s.thetaToLatLoc(theta-spread) + 0.5))
+		latHi = int64(math.Floor(s.thetaToLatLoc(theta+spread) + 0.5))
+		if theta-spread > 0 && theta+spread < math.Pi {
+			if sinSpread := math.Sin(spread) / math.Sin(theta); sinSpread < 1 {
+				phi := math.Atan2(y, x)
+				dphi := math.Asin(sinSpread)
+				lonLo = int64(math.Floor((phi-dphi)*float64(p.LonCells)/(2*math.Pi) + 0.5))
+				lonHi = int64(math.Floor((phi+dphi)*float64(p.LonCells)/(2*math.Pi) + 0.5))
+			}
+		}
+	}
+	altLo, altHi = int64(math.Max(0, float64(altLo))), int64(math.Min(float64(p.Spec.AltCells-1), float64(altHi)))
+	latLo, latHi = int64(math.Max(0, float64(latLo))), int64(math.Min(float64(p.LatCells-1), float64(latHi)))
+
+	shells := []cellRegion{}
+	seen := make(map[[3]float64]bool)
+	for lon := lonLo; lon <= lonHi; lon++ {
+		for lat := latLo; lat <= latHi; lat++ {
+			for alt := altLo; alt <= altHi; alt++ {
+				ind := pb.CellIndex{Lon: (lon%p.LonCells + p.LonCells) % p.LonCells, Lat: lat, Alt: alt}
+				lonMin, _, latMin, _, altMin, _ := p.cellBounds(ind)
+				key := [3]float64{lonMin, latMin, altMin}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				cell := p.CellIndexToCell(ind)
+				if cell != nil && cell.Material != pb.Material_AIR {
+					shells = append(shells, s.cellRegion(ind))
+				}
+			}
+		}
+	}
+	return shells
+}
@@ -0,0 +1,245 @@
+package common
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// NearestMaterial returns the material whose color is closest to a color
+func NearestMaterial(color mgl32.Vec3) pb.Material {
+	nearest := pb.Material_AIR
+	best := float32(-1)
+	for material, c := range MaterialColors {
+		if material == int(pb.Material_AIR) {
+			continue
+		}
+		d := color.Sub(c)
+		if dist := d.Dot(d); best < 0 || dist < best {
+			nearest, best = pb.Material(material), dist
+		}
+	}
+	return nearest
+}
+
+// ReadStructure reads the voxels of a MagicaVoxel .vox or JSON structure file, going by its extension
+func ReadStructure(path string) ([]*pb.Voxel, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".vox":
+		return ReadVox(f)
+	case ".json":
+		return ReadJSONStructure(f)
+	}
+	return nil, fmt.Errorf("unknown structure format %v", filepath.Ext(path))
+}
+
+// ReadVox reads the voxels of the first model in a MagicaVoxel .vox file, giving each the material nearest its color.
+// Files with no palette use the MagicaVoxel default palette.
+func ReadVox(r io.Reader) ([]*pb.Voxel, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 8 || string(data[:4]) != "VOX " {
+		return nil, fmt.Errorf("not a .vox file")
+	}
+
+	// Chunks are read in order, skipping the ones not needed, and MAIN holds the rest as its children
+	var xyzi, rgba []byte
+	for rest := data[8:]; len(rest) > 0; {
+		if len(rest) < 12 {
+			return nil, fmt.Errorf("truncated .vox chunk")
+		}
+		id := string(rest[:4])
+		content := int(binary.LittleEndian.Uint32(rest[4:8]))
+		children := int(binary.LittleEndian.Uint32(rest[8:12]))
+		rest = rest[12:]
+		if content < 0 || children < 0 || content+children > len(rest) {
+			return nil, fmt.Errorf("truncated %v chunk", id)
+		}
+		switch id {
+		case "MAIN":
+			rest = rest[content : content+children]
+			continue
+		case "XYZI":
+			if xyzi == nil {
+				xyzi = rest[:content]
+			}
+		case "RGBA":
+			rgba = rest[:content]
+		}
+		rest = rest[content+children:]
+	}
+	if xyzi == nil || len(xyzi) < 4 {
+		return nil, fmt.Errorf("no model in .vox file")
+	}
+	if rgba == nil {
+		rgba = voxDefaultPalette
+	}
+	if len(rgba) < 256*4 {
+		return nil, fmt.Errorf("truncated RGBA chunk")
+	}
+
+	// Palette entry i is the color of index i+1
+	materials := [256]pb.Material{}
+	for i := 1; i < 256; i++ {
+		c := rgba[(i-1)*4:]
+		materials[i] = NearestMaterial(mgl32.Vec3{float32(c[0]) / 255, float32(c[1]) / 255, float32(c[2]) / 255})
+		// WriteVox gives each material its own index, so keep materials that share a color apart
+		if i < len(MaterialColors) {
+			m := MaterialColors[i]
+			if c[0] == byte(m[0]*255) && c[1] == byte(m[1]*255) && c[2] == byte(m[2]*255) {
+				materials[i] = pb.Material(i)
+			}
+		}
+	}
+	count := int(binary.LittleEndian.Uint32(xyzi))
+	if count < 0 || 4+count*4 > len(xyzi) {
+		return nil, fmt.Errorf("truncated XYZI chunk")
+	}
+	voxels := make([]*pb.Voxel, 0, count)
+	for i := 0; i < count; i++ {
+		v := xyzi[4+i*4:]
+		if v[3] == 0 {
+			continue
+		}
+		voxels = append(voxels, &pb.Voxel{X: int64(v[0]), Y: int64(v[1]), Z: int64(v[2]), Material: materials[v[3]]})
+	}
+	return voxels, nil
+}
+
+// jsonVoxel is a voxel of a JSON structure file, with either the name of its material or an RGB color from 0 to 255
+type jsonVoxel struct {
+	X        int64     `json:"x"`
+	Y        int64     `json:"y"`
+	Z        int64     `json:"z"`
+	Material string    `json:"material"`
+	Color    *[3]uint8 `json:"color"`
+}
+
+// ReadJSONStructure reads the voxels of a JSON structure file, which looks like
+//
+//	{"voxels": [{"x": 0, "y": 0, "z": 0, "material": "STONE"}, {"x": 0, "y": 0, "z": 1, "color": [255, 128, 128]}]}
+func ReadJSONStructure(r io.Reader) ([]*pb.Voxel, error) {
+	var structure struct {
+		Voxels []jsonVoxel `json:"voxels"`
+	}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&structure); err != nil {
+		return nil, err
+	}
+	voxels := make([]*pb.Voxel, 0, len(structure.Voxels))
+	for _, v := range structure.Voxels {
+		voxel := pb.Voxel{X: v.X, Y: v.Y, Z: v.Z}
+		switch {
+		case v.Material != "":
+			material, ok := pb.Material_value[strings.ToUpper(v.Material)]
+			if !ok {
+				return nil, fmt.Errorf("unknown material %v", v.Material)
+			}
+			voxel.Material = pb.Material(material)
+		case v.Color != nil:
+			voxel.Material = NearestMaterial(mgl32.Vec3{float32(v.Color[0]) / 255, float32(v.Color[1]) / 255, float32(v.Color[2]) / 255})
+		default:
+			return nil, fmt.Errorf("voxel at %v,%v,%v has no material or color", v.X, v.Y, v.Z)
+		}
+		voxels = append(voxels, &voxel)
+	}
+	return voxels, nil
+}
+
+// StructureCells returns the cells a structure covers when placed with its base centered on an origin cell and
+// turned a number of quarter turns counterclockwise about the local up direction.
+// A voxel's x, y and z follow longitude, latitude and altitude from the origin, as in WriteVox, so the structure
+// stands upright on the curved grid. Voxels that fall outside the planet are left out.
+func (p *Planet) StructureCells(voxels []*pb.Voxel, origin pb.CellIndex, turns int64) map[CellKey]pb.Material {
+	cells := map[CellKey]pb.Material{}
+	if len(voxels) == 0 {
+		return cells
+	}
+	minX, maxX, minY, maxY, minZ := voxels[0].X, voxels[0].X, voxels[0].Y, voxels[0].Y, voxels[0].Z
+	for _, v := range voxels {
+		minX, maxX = min64(minX, v.X), max64(maxX, v.X)
+		minY, maxY = min64(minY, v.Y), max64(maxY, v.Y)
+		minZ = min64(minZ, v.Z)
+	}
+	for _, v := range voxels {
+		dx, dy := v.X-(minX+maxX)/2, v.Y-(minY+maxY)/2
+		for t := (turns%4 + 4) % 4; t > 0; t-- {
+			dx, dy = -dy, dx
+		}
+		ind, ok := p.NeighborCellIndex(origin, dx, dy, v.Z-minZ)
+		if !ok {
+			continue
+		}
+		cells[CellKey{Lon: ind.Lon, Lat: ind.Lat, Alt: ind.Alt}] = v.Material
+	}
+	return cells
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// maxStructureSize is the most cells a placed structure may span along each axis, the size of the largest .vox model.
+// It bounds how many cells one batch sets while it holds the chunk locks.
+const maxStructureSize = voxMaxSize
+
+// checkStructureSize returns an error if a structure spans more than maxStructureSize cells along an axis,
+// or has more voxels than fit in that space
+func checkStructureSize(voxels []*pb.Voxel) error {
+	if len(voxels) > maxStructureSize*maxStructureSize*maxStructureSize {
+		return fmt.Errorf("structure has %v voxels, more than fit in %v cells along each side", len(voxels), maxStructureSize)
+	}
+	if len(voxels) == 0 {
+		return nil
+	}
+	minX, maxX, minY, maxY, minZ, maxZ := voxels[0].X, voxels[0].X, voxels[0].Y, voxels[0].Y, voxels[0].Z, voxels[0].Z
+	for _, v := range voxels {
+		minX, maxX = min64(minX, v.X), max64(maxX, v.X)
+		minY, maxY = min64(minY, v.Y), max64(maxY, v.Y)
+		minZ, maxZ = min64(minZ, v.Z), max64(maxZ, v.Z)
+	}
+	for _, span := range []int64{maxX - minX, maxY - minY, maxZ - minZ} {
+		if span >= maxStructureSize {
+			return fmt.Errorf("structure spans %v by %v by %v cells, more than %v along a side", maxX-minX+1, maxY-minY+1, maxZ-minZ+1, maxStructureSize)
+		}
+	}
+	return nil
+}
+
+// PlaceStructure sets the cells covered by a structure, as laid out by StructureCells, in one batch with
+// SetCellMaterials, returning how many cells changed. Structures larger than the largest .vox model are refused.
+func (p *Planet) PlaceStructure(voxels []*pb.Voxel, origin pb.CellIndex, turns int64) (int, error) {
+	if p.CellIndexToCell(origin) == nil {
+		return 0, fmt.Errorf("cell %v,%v,%v is not on planet %v", origin.Lon, origin.Lat, origin.Alt, p.Spec.Id)
+	}
+	if err := checkStructureSize(voxels); err != nil {
+		return 0, err
+	}
+	return p.SetCellMaterials(p.StructureCells(voxels, origin, turns))
+}
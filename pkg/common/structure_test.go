@@ -0,0 +1,163 @@
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+func TestVoxRoundTrip(t *testing.T) {
+	p := NewPlanet(nil, NewMemoryChunkStore(), pb.PlanetSpec{Name: "t", Radius: 64, AltCells: 64, Seed: 1, GeneratorType: "earth"})
+
+	// A row of every material, including the ones that share a color
+	voxels := []*pb.Voxel{}
+	for m := 1; m < len(MaterialColors); m++ {
+		voxels = append(voxels, &pb.Voxel{X: int64(m), Material: pb.Material(m)})
+	}
+	origin := pb.CellIndex{Lon: 40, Lat: 30, Alt: 60}
+	if _, err := p.PlaceStructure(voxels, origin, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	const radius = 14
+	var buf bytes.Buffer
+	if err := p.WriteVox(&buf, origin, radius, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadVox(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) == 0 {
+		t.Fatal("read no voxels")
+	}
+	for _, v := range read {
+		ind, ok := p.NeighborCellIndex(origin, v.X-radius, v.Y-1, v.Z-1)
+		if !ok {
+			t.Fatalf("voxel %v,%v,%v is off the planet", v.X, v.Y, v.Z)
+		}
+		if cell := p.CellIndexToCell(ind); cell.Material != v.Material {
+			t.Errorf("voxel %v,%v,%v is %v, want %v", v.X, v.Y, v.Z, v.Material, cell.Material)
+		}
+	}
+
+	if err := p.WriteVox(&buf, origin, voxMaxSize, 0, 0); err == nil {
+		t.Error("wrote a region too large for a .vox model")
+	}
+}
+
+// testVox builds a .vox file of one model with an optional palette
+func testVox(xyzi [][4]byte, rgba []byte) []byte {
+	var sizeContent, xyziContent bytes.Buffer
+	binary.Write(&sizeContent, binary.LittleEndian, [3]int32{8, 8, 8})
+	binary.Write(&xyziContent, binary.LittleEndian, int32(len(xyzi)))
+	for _, v := range xyzi {
+		xyziContent.Write(v[:])
+	}
+	var children bytes.Buffer
+	voxChunk(&children, "SIZE", sizeContent.Bytes(), nil)
+	voxChunk(&children, "XYZI", xyziContent.Bytes(), nil)
+	if rgba != nil {
+		voxChunk(&children, "RGBA", rgba, nil)
+	}
+	var file bytes.Buffer
+	file.WriteString("VOX ")
+	binary.Write(&file, binary.LittleEndian, int32(150))
+	voxChunk(&file, "MAIN", nil, children.Bytes())
+	return file.Bytes()
+}
+
+func TestReadVoxDefaultPalette(t *testing.T) {
+	if len(voxDefaultPalette) != 256*4 {
+		t.Fatalf("default palette has %v bytes, want %v", len(voxDefaultPalette), 256*4)
+	}
+	// Spot checks against the MagicaVoxel default palette
+	for index, want := range map[int][3]byte{1: {0xff, 0xff, 0xff}, 2: {0xff, 0xff, 0xcc}, 215: {0, 0, 0x33}, 216: {0, 0, 0xee}, 245: {0x11, 0, 0}, 255: {0x11, 0x11, 0x11}} {
+		if c := voxDefaultPalette[(index-1)*4:]; c[0] != want[0] || c[1] != want[1] || c[2] != want[2] {
+			t.Errorf("default color %v is %v, want %v", index, c[:3], want)
+		}
+	}
+
+	voxels, err := ReadVox(bytes.NewReader(testVox([][4]byte{{0, 1, 2, 1}, {3, 4, 5, 227}, {6, 7, 0, 245}}, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*pb.Voxel{
+		{X: 0, Y: 1, Z: 2, Material: NearestMaterial(mgl32.Vec3{1, 1, 1})},
+		{X: 3, Y: 4, Z: 5, Material: NearestMaterial(mgl32.Vec3{0, float32(0xdd) / 255, 0})},
+		{X: 6, Y: 7, Z: 0, Material: NearestMaterial(mgl32.Vec3{float32(0x11) / 255, 0, 0})},
+	}
+	if len(voxels) != len(want) {
+		t.Fatalf("read %v voxels, want %v", len(voxels), len(want))
+	}
+	for i, v := range voxels {
+		if v.X != want[i].X || v.Y != want[i].Y || v.Z != want[i].Z || v.Material != want[i].Material {
+			t.Errorf("voxel %v is %v, want %v", i, v, want[i])
+		}
+	}
+
+	if _, err := ReadVox(bytes.NewReader(testVox([][4]byte{{0, 0, 0, 1}}, make([]byte, 16)))); err == nil {
+		t.Error("read a .vox file with a truncated palette")
+	}
+	if _, err := ReadVox(strings.NewReader("not a vox file")); err == nil {
+		t.Error("read a file that is not .vox")
+	}
+}
+
+func TestReadJSONStructure(t *testing.T) {
+	voxels, err := ReadJSONStructure(strings.NewReader(`{"voxels": [
+		{"x": 0, "y": 0, "z": 0, "material": "STONE"},
+		{"x": 1, "y": -2, "z": 3, "material": "gold_ore"},
+		{"x": 0, "y": 0, "z": 1, "color": [255, 128, 128]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*pb.Voxel{
+		{X: 0, Y: 0, Z: 0, Material: pb.Material_STONE},
+		{X: 1, Y: -2, Z: 3, Material: pb.Material_GOLD_ORE},
+		{X: 0, Y: 0, Z: 1, Material: NearestMaterial(mgl32.Vec3{1, float32(128) / 255, float32(128) / 255})},
+	}
+	if len(voxels) != len(want) {
+		t.Fatalf("read %v voxels, want %v", len(voxels), len(want))
+	}
+	for i, v := range voxels {
+		if v.X != want[i].X || v.Y != want[i].Y || v.Z != want[i].Z || v.Material != want[i].Material {
+			t.Errorf("voxel %v is %v, want %v", i, v, want[i])
+		}
+	}
+
+	for _, bad := range []string{
+		`{"voxels": [{"x": 0, "y": 0, "z": 0, "material": "UNOBTAINIUM"}]}`,
+		`{"voxels": [{"x": 0, "y": 0, "z": 0}]}`,
+		`{"voxels": [{"x": 0, "y": 0, "z": 0, "material": "STONE", "weight": 3}]}`,
+		`{"voxels": [`,
+	} {
+		if _, err := ReadJSONStructure(strings.NewReader(bad)); err == nil {
+			t.Errorf("read bad structure %v", bad)
+		}
+	}
+}
+
+func TestPlaceStructureSize(t *testing.T) {
+	p := NewPlanet(nil, NewMemoryChunkStore(), pb.PlanetSpec{Name: "t", Radius: 64, AltCells: 64, Seed: 1, GeneratorType: "earth"})
+	origin := pb.CellIndex{Lon: 40, Lat: 30, Alt: 60}
+	tests := []struct {
+		voxels []*pb.Voxel
+		ok     bool
+	}{
+		{[]*pb.Voxel{{X: 0, Material: pb.Material_STONE}, {X: voxMaxSize - 1, Material: pb.Material_STONE}}, true},
+		{[]*pb.Voxel{{X: 0, Material: pb.Material_STONE}, {X: voxMaxSize, Material: pb.Material_STONE}}, false},
+		{[]*pb.Voxel{{Y: -1, Material: pb.Material_STONE}, {Y: voxMaxSize - 1, Material: pb.Material_STONE}}, false},
+		{[]*pb.Voxel{{Z: 0, Material: pb.Material_STONE}, {Z: voxMaxSize, Material: pb.Material_STONE}}, false},
+	}
+	for i, test := range tests {
+		if _, err := p.PlaceStructure(test.voxels, origin, 0); (err == nil) != test.ok {
+			t.Errorf("structure %v: error %v, want ok %v", i, err, test.ok)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package common
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// Names of the topologies a PlanetSpec may ask for. An empty topology is a sphere.
+const (
+	SphereTopology = "sphere"
+	CubeTopology   = "cube"
+	RingTopology   = "ring"
+	FlatTopology   = "flat"
+)
+
+// Topology maps the cell grid of a planet to positions in the planet's frame.
+// It decides how many cells the grid has, where each lies, which way is up, and how the grid wraps around.
+type Topology interface {
+	// CellLocToCartesian converts floating-point cell indices to world coordinates
+	CellLocToCartesian(l pb.CellLoc) mgl32.Vec3
+
+	// CellVertexToCartesian converts floating-point cell indices near a cell, such as its corners, to world coordinates
+	CellVertexToCartesian(ind pb.CellIndex, l pb.CellLoc) mgl32.Vec3
+
+	// CartesianToCellLoc converts world coordinates to floating-point cell indices
+	CartesianToCellLoc(cart mgl32.Vec3) pb.CellLoc
+
+	// NearestCellCenter converts floating-point cell indices to the integral indices of the cell holding them, wrapping around the grid
+	NearestCellCenter(l pb.CellLoc) pb.CellLoc
+
+	// NeighborCellIndex returns the cell index a number of cells away from another along each axis, and false if it is off the grid
+	NeighborCellIndex(ind pb.CellIndex, dLon, dLat, dAlt int64) (pb.CellIndex, bool)
+
+	// LonLatCellsInChunkIndex returns the number of longitude and latitude cells in a chunk
+	LonLatCellsInChunkIndex(ind pb.ChunkIndex) (lonCells, latCells int)
+
+	// Up returns the unit vector pointing up, away from the ground, at world coordinates
+	Up(cart mgl32.Vec3) mgl32.Vec3
+
+	// Latitude returns the latitude in degrees of a location for climate, from -90 to 90
+	Latitude(l pb.CellLoc) float64
+
+	// SpawnLoc returns the floating-point cell indices of the column players spawn in
+	SpawnLoc() pb.CellLoc
+
+	// Closed returns whether the world is a closed body that players may leave for space
+	Closed() bool
+
+	// geometrySampleLoc returns the column of cells under a sample of the low-resolution geometry
+	geometrySampleLoc(lon, lat, lonSamples, latSamples int) pb.CellLoc
+
+	// cellRegion returns the region of space covered by the cell containing a cell index
+	cellRegion(ind pb.CellIndex) cellRegion
+
+	// stepAcross returns the cell entered by leaving a cell through one of its sides at a point,
+	// the side of the new cell entered through, and false if the step leaves the grid
+	stepAcross(ind pb.CellIndex, exit CellFace, pt mgl32.Vec3) (pb.CellIndex, CellFace, bool)
+
+	// solidCellsNear returns the regions of the solid cells within a distance of a point, listing merged cells once
+	solidCellsNear(center mgl32.Vec3, reach float64) []cellRegion
+}
+
+var topologies map[string](func(*Planet) Topology)
+
+func init() {
+	topologies = make(map[string](func(*Planet) Topology))
+	topologies[SphereTopology] = newSphereTopology
+	topologies[CubeTopology] = newCubeTopology
+	topologies[RingTopology] = newRingTopology
+	topologies[FlatTopology] = newFlatTopology
+}
+
+// oppositeFace returns the face of a cell facing the other way
+func oppositeFace(f CellFace) CellFace {
+	switch f {
+	case LonMinFace:
+		return LonMaxFace
+	case LonMaxFace:
+		return LonMinFace
+	case LatMinFace:
+		return LatMaxFace
+	case LatMaxFace:
+		return LatMinFace
+	case AltMinFace:
+		return AltMaxFace
+	case AltMaxFace:
+		return AltMinFace
+	}
+	return NoFace
+}
+
+// faceSteps returns the steps along each axis that cross a face of a cell
+func faceSteps(f CellFace) (dLon, dLat, dAlt int64) {
+	switch f {
+	case LonMinFace:
+		dLon = -1
+	case LonMaxFace:
+		dLon = 1
+	case LatMinFace:
+		dLat = -1
+	case LatMaxFace:
+		dLat = 1
+	case AltMinFace:
+		dAlt = -1
+	case AltMaxFace:
+		dAlt = 1
+	}
+	return
+}
+
+// gridStepAcross returns the cell entered by leaving a cell through one of its sides, for topologies whose
+// cells line up with their neighbors side to side. Altitude may be outside the cells, as it is for a ray arriving from above.
+func (p *Planet) gridStepAcross(ind pb.CellIndex, exit CellFace) (pb.CellIndex, CellFace, bool) {
+	dLon, dLat, dAlt := faceSteps(exit)
+	alt := ind.Alt + dAlt
+	ind.Alt = 0
+	next, ok := p.Topology.NeighborCellIndex(ind, dLon, dLat, 0)
+	next.Alt = alt
+	return next, oppositeFace(exit), ok
+}
+
+// gridGeometrySampleLoc returns the column of cells under a sample of the low-resolution geometry, spreading samples
+// evenly over the longitude and latitude indices and reaching both ends of the latitude range
+func (p *Planet) gridGeometrySampleLoc(lon, lat, lonSamples, latSamples int) pb.CellLoc {
+	return pb.CellLoc{
+		Lon: math.Floor(float64(p.LonCells) * float64(lon) / float64(lonSamples)),
+		Lat: math.Floor(float64(p.LatCells) * float64(lat) / float64(latSamples-1)),
+	}
+}
+
+// gridLatitude returns a latitude for climate that runs evenly from 90 to -90 across the latitude cells
+func (p *Planet) gridLatitude(l pb.CellLoc) float64 {
+	return 90 - 180*(l.Lat+0.5)/float64(p.LatCells)
+}
+
+// reduceNearCore lowers the longitude and latitude cells in a chunk close to the center of a planet, where cells crowd together
+func (p *Planet) reduceNearCore(ind pb.ChunkIndex, lonCells, latCells int) (int, int) {
+	height := (float64(ind.Alt) + 0.5) * float64(p.ChunkSize) * p.AltDelta
+	if height < p.Spec.Radius/4 {
+		lonCells = halveCells(lonCells)
+		latCells = halveCells(latCells)
+	}
+	if height < p.Spec.Radius/8 {
+		lonCells = halveCells(lonCells)
+		latCells = halveCells(latCells)
+	}
+	return lonCells, latCells
+}
+
+// halveCells halves a number of cells across a chunk, keeping at least one
+func halveCells(cells int) int {
+	if cells > 1 {
+		return cells / 2
+	}
+	return cells
+}
+
+// gridNeighborCellIndex steps across a grid whose cells line up with their neighbors, optionally wrapping around in longitude
+func (p *Planet) gridNeighborCellIndex(ind pb.CellIndex, dLon, dLat, dAlt int64, wrapLon bool) (pb.CellIndex, bool) {
+	ind.Alt += dAlt
+	if ind.Alt < 0 || ind.Alt >= p.Spec.AltCells {
+		return ind, false
+	}
+	ind.Lon += dLon
+	if wrapLon {
+		ind.Lon = (ind.Lon%p.LonCells + p.LonCells) % p.LonCells
+	} else if ind.Lon < 0 || ind.Lon >= p.LonCells {
+		return ind, false
+	}
+	ind.Lat += dLat
+	return ind, ind.Lat >= 0 && ind.Lat < p.LatCells
+}
+
+// gridSolidCellsNear returns the regions of the solid cells within a distance of a point, searching outward across
+// the grid from the cell under the point. Width is the narrowest a cell may be across the surface near the point.
+func (p *Planet) gridSolidCellsNear(center mgl32.Vec3, reach, width float64) []cellRegion {
+	loc := p.CartesianToCellLoc(center)
+	altLo := int64(math.Max(0, math.Floor(loc.Alt-reach/p.AltDelta+0.5)))
+	altHi := int64(math.Min(float64(p.Spec.AltCells-1), math.Floor(loc.Alt+reach/p.AltDelta+0.5)))
+	if altLo > altHi {
+		return nil
+	}
+	span := int64(math.Ceil(reach/width)) + 1
+	under := p.CellLocToCellIndex(loc)
+	under.Alt = altLo
+
+	cells := []cellRegion{}
+	seen := make(map[[3]float64]bool)
+	for dLon := -span; dLon <= span; dLon++ {
+		for dLat := -span; dLat <= span; dLat++ {
+			column, ok := p.Topology.NeighborCellIndex(under, dLon, dLat, 0)
+			if !ok {
+				continue
+			}
+			for alt := altLo; alt <= altHi; alt++ {
+				ind := column
+				ind.Alt = alt
+				lonMin, _, latMin, _, altMin, _ := p.cellBounds(ind)
+				key := [3]float64{lonMin, latMin, altMin}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				cell := p.CellIndexToCell(ind)
+				if cell != nil && cell.Material != pb.Material_AIR {
+					cells = append(cells, p.Topology.cellRegion(ind))
+				}
+			}
+		}
+	}
+	return cells
+}
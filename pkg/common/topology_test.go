@@ -0,0 +1,180 @@
+package common
+
+import (
+	"math"
+	"testing"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// topologySystems are systems whose spawn planet has each topology
+var topologySystems = map[string]string{
+	SphereTopology: "planet",
+	CubeTopology:   "cube",
+	RingTopology:   "ring",
+	FlatTopology:   "flat",
+}
+
+// testTopologyPlanet returns the spawn planet of the system with a topology
+func testTopologyPlanet(topology string) *Planet {
+	return NewPlanet(nil, NewMemoryChunkStore(), *systems[topologySystems[topology]](1)[0])
+}
+
+// sampleCells returns cells spread over a planet, including the ones on the edges of the grid
+func sampleCells(p *Planet) []pb.CellIndex {
+	spread := func(cells int64) []int64 {
+		s := []int64{0, 1, cells / 2, cells - 2, cells - 1}
+		for i := int64(3); i < cells; i += cells / 7 {
+			s = append(s, i)
+		}
+		return s
+	}
+	cells := []pb.CellIndex{}
+	for _, lon := range spread(p.LonCells) {
+		for _, lat := range spread(p.LatCells) {
+			for _, alt := range []int64{p.Spec.AltCells / 4, p.Spec.AltCells / 2, p.Spec.AltCells - 1} {
+				cells = append(cells, pb.CellIndex{Lon: lon, Lat: lat, Alt: alt})
+			}
+		}
+	}
+	return cells
+}
+
+// sameCell returns whether two cell indices are the same cell
+func sameCell(a, b pb.CellIndex) bool {
+	return a.Lon == b.Lon && a.Lat == b.Lat && a.Alt == b.Alt
+}
+
+func TestTopologyCartesianRoundTrip(t *testing.T) {
+	for topology := range topologySystems {
+		p := testTopologyPlanet(topology)
+		for _, ind := range sampleCells(p) {
+			loc := p.CellIndexToCellLoc(ind)
+			back := p.CartesianToCellLoc(p.CellLocToCartesian(loc))
+			dLon := math.Remainder(back.Lon-loc.Lon, float64(p.LonCells))
+			if math.Abs(dLon) > 1e-3 || math.Abs(back.Lat-loc.Lat) > 1e-3 || math.Abs(back.Alt-loc.Alt) > 1e-3 {
+				t.Errorf("%v: %v went to %v and back to %v", topology, loc, p.CellLocToCartesian(loc), back)
+			}
+			if center := p.Topology.NearestCellCenter(back); center.Lon != loc.Lon || center.Lat != loc.Lat || center.Alt != loc.Alt {
+				t.Errorf("%v: nearest cell center of %v is %v, want %v", topology, back, center, loc)
+			}
+		}
+	}
+}
+
+func TestTopologyNeighborCellIndex(t *testing.T) {
+	steps := [][2]int64{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	for topology := range topologySystems {
+		p := testTopologyPlanet(topology)
+		for _, ind := range sampleCells(p) {
+			for _, step := range steps {
+				next, ok := p.NeighborCellIndex(ind, step[0], step[1], 0)
+				if !ok {
+					continue
+				}
+				if next.Lon < 0 || next.Lon >= p.LonCells || next.Lat < 0 || next.Lat >= p.LatCells || next.Alt != ind.Alt {
+					t.Errorf("%v: step %v from %v went off the grid to %v", topology, step, ind, next)
+					continue
+				}
+
+				// Neighbors touch, so halfway between them is in one or the other, and stepping back returns to the start
+				mid := p.CellLocToCartesian(p.CellIndexToCellLoc(ind)).Add(p.CellLocToCartesian(p.CellIndexToCellLoc(next))).Mul(0.5)
+				if c := p.CartesianToCellIndex(mid); !sameCell(c, ind) && !sameCell(c, next) {
+					t.Errorf("%v: halfway between %v and %v is in %v", topology, ind, next, c)
+				}
+				if _, ok := p.NeighborCellIndex(next, -step[0], -step[1], 0); !ok {
+					t.Errorf("%v: could not step back from %v to %v", topology, next, ind)
+				}
+				if topology != CubeTopology {
+					if back, _ := p.NeighborCellIndex(next, -step[0], -step[1], 0); !sameCell(back, ind) {
+						t.Errorf("%v: step %v from %v to %v stepped back to %v", topology, step, ind, next, back)
+					}
+				}
+			}
+			for _, dAlt := range []int64{-ind.Alt - 1, p.Spec.AltCells - ind.Alt} {
+				if next, ok := p.NeighborCellIndex(ind, 0, 0, dAlt); ok {
+					t.Errorf("%v: altitude step %v from %v stayed on the grid at %v", topology, dAlt, ind, next)
+				}
+			}
+		}
+	}
+}
+
+func TestTopologyEdges(t *testing.T) {
+	tests := []struct {
+		topology   string
+		ind        pb.CellIndex
+		dLon, dLat int64
+		want       pb.CellIndex
+		ok         bool
+	}{
+		// Longitude wraps around a ring and a sphere, and runs off the sides of a flat world
+		{RingTopology, pb.CellIndex{Lon: -1, Lat: 5, Alt: 3}, 1, 0, pb.CellIndex{Lon: 0, Lat: 5, Alt: 3}, true},
+		{RingTopology, pb.CellIndex{Lon: 0, Lat: 5, Alt: 3}, -1, 0, pb.CellIndex{Lon: -1, Lat: 5, Alt: 3}, true},
+		{RingTopology, pb.CellIndex{Lon: -1, Lat: 5, Alt: 3}, 5, 0, pb.CellIndex{Lon: 4, Lat: 5, Alt: 3}, true},
+		{SphereTopology, pb.CellIndex{Lon: -1, Lat: 5, Alt: 3}, 1, 0, pb.CellIndex{Lon: 0, Lat: 5, Alt: 3}, true},
+		{FlatTopology, pb.CellIndex{Lon: -1, Lat: 5, Alt: 3}, 1, 0, pb.CellIndex{}, false},
+		{FlatTopology, pb.CellIndex{Lon: 0, Lat: 5, Alt: 3}, -1, 0, pb.CellIndex{}, false},
+
+		// Latitude ends at the rims of a ring and the edges of a flat world
+		{RingTopology, pb.CellIndex{Lon: 3, Lat: 0, Alt: 3}, 0, -1, pb.CellIndex{}, false},
+		{RingTopology, pb.CellIndex{Lon: 3, Lat: -1, Alt: 3}, 0, 1, pb.CellIndex{}, false},
+		{FlatTopology, pb.CellIndex{Lon: 3, Lat: 0, Alt: 3}, 0, -1, pb.CellIndex{}, false},
+		{FlatTopology, pb.CellIndex{Lon: 3, Lat: -1, Alt: 3}, 0, 1, pb.CellIndex{}, false},
+		{FlatTopology, pb.CellIndex{Lon: -2, Lat: -2, Alt: 3}, 1, 1, pb.CellIndex{Lon: -1, Lat: -1, Alt: 3}, true},
+	}
+	for _, test := range tests {
+		p := testTopologyPlanet(test.topology)
+
+		// Negative indices count back from the last cell
+		last := func(ind pb.CellIndex) pb.CellIndex {
+			if ind.Lon < 0 {
+				ind.Lon += p.LonCells
+			}
+			if ind.Lat < 0 {
+				ind.Lat += p.LatCells
+			}
+			return ind
+		}
+		ind := last(test.ind)
+		got, ok := p.NeighborCellIndex(ind, test.dLon, test.dLat, 0)
+		if ok != test.ok || (ok && !sameCell(got, last(test.want))) {
+			t.Errorf("%v: step %v,%v from %v is %v, %v, want %v, %v", test.topology, test.dLon, test.dLat, ind, got, ok, last(test.want), test.ok)
+		}
+		if !ok {
+			continue
+		}
+
+		// Stepping across a wrap moves as far as the same step in the middle of the grid
+		middle := pb.CellIndex{Lon: p.LonCells / 2, Lat: ind.Lat, Alt: ind.Alt}
+		inside, _ := p.NeighborCellIndex(middle, test.dLon, test.dLat, 0)
+		distance := func(a, b pb.CellIndex) float32 {
+			return p.CellLocToCartesian(p.CellIndexToCellLoc(a)).Sub(p.CellLocToCartesian(p.CellIndexToCellLoc(b))).Len()
+		}
+		if d, want := distance(ind, got), distance(middle, inside); math.Abs(float64(d-want)) > 1e-3 {
+			t.Errorf("%v: step %v,%v from %v to %v moved %v, want %v", test.topology, test.dLon, test.dLat, ind, got, d, want)
+		}
+	}
+}
+
+func TestTopologyUp(t *testing.T) {
+	for topology := range topologySystems {
+		p := testTopologyPlanet(topology)
+		for _, ind := range sampleCells(p) {
+			loc := p.CellIndexToCellLoc(ind)
+			cart := p.CellLocToCartesian(loc)
+			up := p.Up(cart)
+			if math.Abs(float64(up.Len())-1) > 1e-5 {
+				t.Errorf("%v: up at %v has length %v", topology, loc, up.Len())
+			}
+
+			// Up points the way altitude increases
+			below, above := loc, loc
+			below.Alt -= 0.5
+			above.Alt += 0.5
+			if dir := p.CellLocToCartesian(above).Sub(p.CellLocToCartesian(below)).Normalize(); dir.Dot(up) < 0.999 {
+				t.Errorf("%v: up at %v is %v, but altitude increases along %v", topology, loc, up, dir)
+			}
+		}
+	}
+}
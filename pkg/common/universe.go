@@ -1,9 +1,7 @@
 package common
 
 import (
-	"bytes"
 	"database/sql"
-	"encoding/gob"
 
 	pb "github.com/jeffbaumes/govox/pkg/govox"
 	opensimplex "github.com/ojrac/opensimplex-go"
@@ -16,12 +14,14 @@ type Universe struct {
 	PlanetMap map[int64]*Planet
 }
 
-// NewUniverse creates a universe with a given seed
-func NewUniverse(db *sql.DB, systemType string) *Universe {
+// NewUniverse creates a universe with a given seed, keeping its planets in a database and their chunks in a store.
+// It returns an error if a stored or generated planet spec is not valid.
+func NewUniverse(db *sql.DB, store ChunkStore, systemType string, seed int64) (*Universe, error) {
 	u := Universe{}
-	u.noise = opensimplex.NewWithSeed(0)
+	u.seed = seed
+	u.noise = opensimplex.NewWithSeed(seed)
 	u.PlanetMap = make(map[int64]*Planet)
-	planetSpecs := queryPlanetSpecs(db)
+	planetSpecs := QueryPlanetSpecs(db)
 
 	// If no planets in the database, generate a planetary system
 	if len(planetSpecs) == 0 {
@@ -29,22 +29,31 @@ func NewUniverse(db *sql.DB, systemType string) *Universe {
 		if systemGen == nil {
 			systemGen = systems["planet"]
 		}
-		planetSpecs = systemGen()
+		planetSpecs = systemGen(seed)
 		for _, spec := range planetSpecs {
-			savePlanetSpec(db, *spec)
+			if err := ValidatePlanetSpec(spec); err != nil {
+				return nil, err
+			}
+		}
+		for _, spec := range planetSpecs {
+			SavePlanetSpec(db, *spec)
 		}
 	}
 
 	// Put the planets in the universe
 	for _, spec := range planetSpecs {
-		planet := NewPlanet(nil, db, *spec)
+		if err := ValidatePlanetSpec(spec); err != nil {
+			return nil, err
+		}
+		planet := NewPlanet(nil, store, *spec)
 		u.PlanetMap[planet.Spec.Id] = planet
 	}
 
-	return &u
+	return &u, nil
 }
 
-func queryPlanetSpecs(db *sql.DB) []*pb.PlanetSpec {
+// QueryPlanetSpecs returns the specs of the planets stored in a world database
+func QueryPlanetSpecs(db *sql.DB) []*pb.PlanetSpec {
 	states := []*pb.PlanetSpec{}
 	rows, err := db.Query("SELECT data FROM planet")
 	if err != nil {
@@ -58,30 +67,19 @@ func queryPlanetSpecs(db *sql.DB) []*pb.PlanetSpec {
 		if err != nil {
 			panic(err)
 		}
-		var dbuf bytes.Buffer
-		dbuf.Write(data)
-		dec := gob.NewDecoder(&dbuf)
-		err = dec.Decode(&val)
-		if err != nil {
-			panic(err)
-		}
+		decodeBlob(data, &val)
 		states = append(states, &val)
 	}
 	return states
 }
 
-func savePlanetSpec(db *sql.DB, spec pb.PlanetSpec) {
+// SavePlanetSpec stores the spec of a planet in a world database
+func SavePlanetSpec(db *sql.DB, spec pb.PlanetSpec) {
 	stmt, err := db.Prepare("INSERT INTO planet VALUES (?, ?)")
 	if err != nil {
 		panic(err)
 	}
-	var buf bytes.Buffer
-	enc := gob.NewEncoder(&buf)
-	err = enc.Encode(spec)
-	if err != nil {
-		panic(err)
-	}
-	_, err = stmt.Exec(spec.Id, buf.Bytes())
+	_, err = stmt.Exec(spec.Id, encodeBlob(&spec))
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// voxMaxSize is the most voxels a MagicaVoxel model holds along each axis
+const voxMaxSize = 256
+
+// voxDefaultPalette is the palette MagicaVoxel uses for files with no RGBA chunk, laid out like RGBA content
+// with entry i the color of index i+1. It holds a 6 by 6 by 6 color cube without black, brightest first,
+// then ramps of blue, green, red and gray.
+var voxDefaultPalette = func() []byte {
+	palette := []byte{}
+	for r := 5; r >= 0; r-- {
+		for g := 5; g >= 0; g-- {
+			for b := 5; b >= 0; b-- {
+				if r+g+b > 0 {
+					palette = append(palette, byte(r*0x33), byte(g*0x33), byte(b*0x33), 255)
+				}
+			}
+		}
+	}
+	ramp := []byte{0xee, 0xdd, 0xbb, 0xaa, 0x88, 0x77, 0x55, 0x44, 0x22, 0x11}
+	for _, channels := range [][3]byte{{0, 0, 1}, {0, 1, 0}, {1, 0, 0}, {1, 1, 1}} {
+		for _, v := range ramp {
+			palette = append(palette, channels[0]*v, channels[1]*v, channels[2]*v, 255)
+		}
+	}
+	return append(palette, 0, 0, 0, 255)
+}()
+
+// voxChunk appends a chunk of a .vox file, which has an id, the sizes of its content and children, and then both
+func voxChunk(buf *bytes.Buffer, id string, content, children []byte) {
+	buf.WriteString(id)
+	binary.Write(buf, binary.LittleEndian, int32(len(content)))
+	binary.Write(buf, binary.LittleEndian, int32(len(children)))
+	buf.Write(content)
+	buf.Write(children)
+}
+
+// WriteVox writes the cells in a box around a center cell as a MagicaVoxel .vox model.
+// Each cell becomes the voxel at its offset from the corner of the box in longitude, latitude and altitude, so
+// the model is upright with the surface flat, and air is left empty. Voxel colors come from MaterialColors.
+func (p *Planet) WriteVox(w io.Writer, center pb.CellIndex, lonRadius, latRadius, altRadius int64) error {
+	size := [3]int64{2*lonRadius + 1, 2*latRadius + 1, 2*altRadius + 1}
+	for _, s := range size {
+		if s < 1 || s > voxMaxSize {
+			return fmt.Errorf("region of %v by %v by %v cells does not fit in a .vox model", size[0], size[1], size[2])
+		}
+	}
+
+	var voxels bytes.Buffer
+	count := 0
+	for dLon := -lonRadius; dLon <= lonRadius; dLon++ {
+		for dLat := -latRadius; dLat <= latRadius; dLat++ {
+			for dAlt := -altRadius; dAlt <= altRadius; dAlt++ {
+				ind, ok := p.NeighborCellIndex(center, dLon, dLat, dAlt)
+				if !ok {
+					continue
+				}
+				cell := p.CellIndexToCell(ind)
+				if cell == nil || cell.Material == pb.Material_AIR {
+					continue
+				}
+				// Palette indices start at one, so each material is its own index
+				voxels.Write([]byte{byte(dLon + lonRadius), byte(dLat + latRadius), byte(dAlt + altRadius), byte(cell.Material)})
+				count++
+			}
+		}
+	}
+
+	var sizeContent, xyziContent, rgbaContent bytes.Buffer
+	binary.Write(&sizeContent, binary.LittleEndian, [3]int32{int32(size[0]), int32(size[1]), int32(size[2])})
+	binary.Write(&xyziContent, binary.LittleEndian, int32(count))
+	xyziContent.Write(voxels.Bytes())
+	// Palette entry i is the color of index i+1
+	for i := 0; i < 256; i++ {
+		color := [4]byte{0, 0, 0, 255}
+		if material := i + 1; material < len(MaterialColors) {
+			c := MaterialColors[material]
+			color = [4]byte{byte(c[0] * 255), byte(c[1] * 255), byte(c[2] * 255), 255}
+		}
+		rgbaContent.Write(color[:])
+	}
+
+	var children bytes.Buffer
+	voxChunk(&children, "SIZE", sizeContent.Bytes(), nil)
+	voxChunk(&children, "XYZI", xyziContent.Bytes(), nil)
+	voxChunk(&children, "RGBA", rgbaContent.Bytes(), nil)
+	var file bytes.Buffer
+	file.WriteString("VOX ")
+	binary.Write(&file, binary.LittleEndian, int32(150))
+	voxChunk(&file, "MAIN", nil, children.Bytes())
+	_, err := w.Write(file.Bytes())
+	return err
+}
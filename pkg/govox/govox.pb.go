@@ -44,6 +44,15 @@ const (
 	Material_YELLOW_BLOCK Material = 13
 	Material_YELLOW_SAND  Material = 14
 	Material_WATER        Material = 15
+	Material_BLUE_WOOD    Material = 16
+	Material_GREEN_WOOD   Material = 17
+	Material_PURPLE_WOOD  Material = 18
+	Material_YELLOW_WOOD  Material = 19
+	Material_BLUE_LEAVES  Material = 20
+	Material_COAL_ORE     Material = 21
+	Material_IRON_ORE     Material = 22
+	Material_GOLD_ORE     Material = 23
+	Material_CRYSTAL_ORE  Material = 24
 )
 
 var Material_name = map[int32]string{
@@ -63,6 +72,15 @@ var Material_name = map[int32]string{
 	13: "YELLOW_BLOCK",
 	14: "YELLOW_SAND",
 	15: "WATER",
+	16: "BLUE_WOOD",
+	17: "GREEN_WOOD",
+	18: "PURPLE_WOOD",
+	19: "YELLOW_WOOD",
+	20: "BLUE_LEAVES",
+	21: "COAL_ORE",
+	22: "IRON_ORE",
+	23: "GOLD_ORE",
+	24: "CRYSTAL_ORE",
 }
 
 var Material_value = map[string]int32{
@@ -82,6 +100,15 @@ var Material_value = map[string]int32{
 	"YELLOW_BLOCK": 13,
 	"YELLOW_SAND":  14,
 	"WATER":        15,
+	"BLUE_WOOD":    16,
+	"GREEN_WOOD":   17,
+	"PURPLE_WOOD":  18,
+	"YELLOW_WOOD":  19,
+	"BLUE_LEAVES":  20,
+	"COAL_ORE":     21,
+	"IRON_ORE":     22,
+	"GOLD_ORE":     23,
+	"CRYSTAL_ORE":  24,
 }
 
 func (x Material) String() string {
@@ -92,6 +119,52 @@ func (Material) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_303e99b6bdde8eb4, []int{0}
 }
 
+type Biome int32
+
+const (
+	Biome_NO_BIOME  Biome = 0
+	Biome_OCEAN     Biome = 1
+	Biome_BEACH     Biome = 2
+	Biome_GRASSLAND Biome = 3
+	Biome_FOREST    Biome = 4
+	Biome_DESERT    Biome = 5
+	Biome_BADLANDS  Biome = 6
+	Biome_TUNDRA    Biome = 7
+	Biome_POLAR     Biome = 8
+)
+
+var Biome_name = map[int32]string{
+	0: "NO_BIOME",
+	1: "OCEAN",
+	2: "BEACH",
+	3: "GRASSLAND",
+	4: "FOREST",
+	5: "DESERT",
+	6: "BADLANDS",
+	7: "TUNDRA",
+	8: "POLAR",
+}
+
+var Biome_value = map[string]int32{
+	"NO_BIOME":  0,
+	"OCEAN":     1,
+	"BEACH":     2,
+	"GRASSLAND": 3,
+	"FOREST":    4,
+	"DESERT":    5,
+	"BADLANDS":  6,
+	"TUNDRA":    7,
+	"POLAR":     8,
+}
+
+func (x Biome) String() string {
+	return proto.EnumName(Biome_name, int32(x))
+}
+
+func (Biome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{1}
+}
+
 type GetPlanetsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -163,16 +236,28 @@ func (m *GetPlanetsResponse) GetPlanets() []*PlanetSpec {
 }
 
 type PlanetSpec struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Radius               float64  `protobuf:"fixed64,3,opt,name=radius,proto3" json:"radius,omitempty"`
-	AltCells             int64    `protobuf:"varint,4,opt,name=altCells,proto3" json:"altCells,omitempty"`
-	OrbitPlanet          int64    `protobuf:"varint,5,opt,name=orbitPlanet,proto3" json:"orbitPlanet,omitempty"`
-	OrbitDistance        float64  `protobuf:"fixed64,6,opt,name=orbitDistance,proto3" json:"orbitDistance,omitempty"`
-	OrbitSeconds         float64  `protobuf:"fixed64,7,opt,name=orbitSeconds,proto3" json:"orbitSeconds,omitempty"`
-	RotationSeconds      float64  `protobuf:"fixed64,8,opt,name=rotationSeconds,proto3" json:"rotationSeconds,omitempty"`
-	Seed                 int64    `protobuf:"varint,9,opt,name=seed,proto3" json:"seed,omitempty"`
-	GeneratorType        string   `protobuf:"bytes,10,opt,name=generatorType,proto3" json:"generatorType,omitempty"`
+	Id                int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Radius            float64 `protobuf:"fixed64,3,opt,name=radius,proto3" json:"radius,omitempty"`
+	AltCells          int64   `protobuf:"varint,4,opt,name=altCells,proto3" json:"altCells,omitempty"`
+	OrbitPlanet       int64   `protobuf:"varint,5,opt,name=orbitPlanet,proto3" json:"orbitPlanet,omitempty"`
+	OrbitDistance     float64 `protobuf:"fixed64,6,opt,name=orbitDistance,proto3" json:"orbitDistance,omitempty"`
+	OrbitSeconds      float64 `protobuf:"fixed64,7,opt,name=orbitSeconds,proto3" json:"orbitSeconds,omitempty"`
+	RotationSeconds   float64 `protobuf:"fixed64,8,opt,name=rotationSeconds,proto3" json:"rotationSeconds,omitempty"`
+	Seed              int64   `protobuf:"varint,9,opt,name=seed,proto3" json:"seed,omitempty"`
+	GeneratorType     string  `protobuf:"bytes,10,opt,name=generatorType,proto3" json:"generatorType,omitempty"`
+	Eccentricity      float64 `protobuf:"fixed64,11,opt,name=eccentricity,proto3" json:"eccentricity,omitempty"`
+	Inclination       float64 `protobuf:"fixed64,12,opt,name=inclination,proto3" json:"inclination,omitempty"`
+	AscendingNode     float64 `protobuf:"fixed64,13,opt,name=ascendingNode,proto3" json:"ascendingNode,omitempty"`
+	OrbitPhase        float64 `protobuf:"fixed64,14,opt,name=orbitPhase,proto3" json:"orbitPhase,omitempty"`
+	AxialTilt         float64 `protobuf:"fixed64,15,opt,name=axialTilt,proto3" json:"axialTilt,omitempty"`
+	TiltDirection     float64 `protobuf:"fixed64,16,opt,name=tiltDirection,proto3" json:"tiltDirection,omitempty"`
+	SurfaceGravity    float64 `protobuf:"fixed64,17,opt,name=surfaceGravity,proto3" json:"surfaceGravity,omitempty"`
+	AtmosphereDensity float64 `protobuf:"fixed64,18,opt,name=atmosphereDensity,proto3" json:"atmosphereDensity,omitempty"`
+	Topology          string  `protobuf:"bytes,19,opt,name=topology,proto3" json:"topology,omitempty"`
+	// Cells along each side of a chunk, which must be a power of two, or zero for the default
+	ChunkSize            int64    `protobuf:"varint,20,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+	CellHeight           float64  `protobuf:"fixed64,21,opt,name=cellHeight,proto3" json:"cellHeight,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -273,6 +358,83 @@ func (m *PlanetSpec) GetGeneratorType() string {
 	return ""
 }
 
+func (m *PlanetSpec) GetEccentricity() float64 {
+	if m != nil {
+		return m.Eccentricity
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetInclination() float64 {
+	if m != nil {
+		return m.Inclination
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetAscendingNode() float64 {
+	if m != nil {
+		return m.AscendingNode
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetOrbitPhase() float64 {
+	if m != nil {
+		return m.OrbitPhase
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetAxialTilt() float64 {
+	if m != nil {
+		return m.AxialTilt
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetTiltDirection() float64 {
+	if m != nil {
+		return m.TiltDirection
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetSurfaceGravity() float64 {
+	if m != nil {
+		return m.SurfaceGravity
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetAtmosphereDensity() float64 {
+	if m != nil {
+		return m.AtmosphereDensity
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetTopology() string {
+	if m != nil {
+		return m.Topology
+	}
+	return ""
+}
+
+func (m *PlanetSpec) GetChunkSize() int64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *PlanetSpec) GetCellHeight() float64 {
+	if m != nil {
+		return m.CellHeight
+	}
+	return 0
+}
+
 type GetChunkRequest struct {
 	Planet               int64       `protobuf:"varint,1,opt,name=planet,proto3" json:"planet,omitempty"`
 	Index                *ChunkIndex `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
@@ -417,6 +579,8 @@ func (m *GetChunkResponse) GetChunk() *Chunk {
 type Chunk struct {
 	Cell                 []*Chunk_CellLat `protobuf:"bytes,1,rep,name=cell,proto3" json:"cell,omitempty"`
 	WaitingForData       bool             `protobuf:"varint,2,opt,name=waitingForData,proto3" json:"waitingForData,omitempty"`
+	Uniform              bool             `protobuf:"varint,3,opt,name=uniform,proto3" json:"uniform,omitempty"`
+	Material             Material         `protobuf:"varint,4,opt,name=material,proto3,enum=govox.Material" json:"material,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -461,6 +625,20 @@ func (m *Chunk) GetWaitingForData() bool {
 	return false
 }
 
+func (m *Chunk) GetUniform() bool {
+	if m != nil {
+		return m.Uniform
+	}
+	return false
+}
+
+func (m *Chunk) GetMaterial() Material {
+	if m != nil {
+		return m.Material
+	}
+	return Material_AIR
+}
+
 type Chunk_CellLat struct {
 	Cell                 []*Chunk_CellAlt `protobuf:"bytes,1,rep,name=cell,proto3" json:"cell,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -621,6 +799,7 @@ type PlanetGeometry struct {
 	Altitude             []*PlanetGeometry_AltitudeRow `protobuf:"bytes,1,rep,name=altitude,proto3" json:"altitude,omitempty"`
 	Material             []*PlanetGeometry_MaterialRow `protobuf:"bytes,2,rep,name=material,proto3" json:"material,omitempty"`
 	IsLoading            bool                          `protobuf:"varint,3,opt,name=isLoading,proto3" json:"isLoading,omitempty"`
+	Biome                []*PlanetGeometry_BiomeRow    `protobuf:"bytes,4,rep,name=biome,proto3" json:"biome,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
 	XXX_unrecognized     []byte                        `json:"-"`
 	XXX_sizecache        int32                         `json:"-"`
@@ -672,6 +851,13 @@ func (m *PlanetGeometry) GetIsLoading() bool {
 	return false
 }
 
+func (m *PlanetGeometry) GetBiome() []*PlanetGeometry_BiomeRow {
+	if m != nil {
+		return m.Biome
+	}
+	return nil
+}
+
 type PlanetGeometry_AltitudeRow struct {
 	Altitude             []int64  `protobuf:"varint,1,rep,packed,name=altitude,proto3" json:"altitude,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -750,6 +936,45 @@ func (m *PlanetGeometry_MaterialRow) GetMaterial() []Material {
 	return nil
 }
 
+type PlanetGeometry_BiomeRow struct {
+	Biome                []Biome  `protobuf:"varint,1,rep,packed,name=biome,proto3,enum=govox.Biome" json:"biome,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlanetGeometry_BiomeRow) Reset()         { *m = PlanetGeometry_BiomeRow{} }
+func (m *PlanetGeometry_BiomeRow) String() string { return proto.CompactTextString(m) }
+func (*PlanetGeometry_BiomeRow) ProtoMessage()    {}
+func (*PlanetGeometry_BiomeRow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{9, 2}
+}
+
+func (m *PlanetGeometry_BiomeRow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanetGeometry_BiomeRow.Unmarshal(m, b)
+}
+func (m *PlanetGeometry_BiomeRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanetGeometry_BiomeRow.Marshal(b, m, deterministic)
+}
+func (m *PlanetGeometry_BiomeRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanetGeometry_BiomeRow.Merge(m, src)
+}
+func (m *PlanetGeometry_BiomeRow) XXX_Size() int {
+	return xxx_messageInfo_PlanetGeometry_BiomeRow.Size(m)
+}
+func (m *PlanetGeometry_BiomeRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanetGeometry_BiomeRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanetGeometry_BiomeRow proto.InternalMessageInfo
+
+func (m *PlanetGeometry_BiomeRow) GetBiome() []Biome {
+	if m != nil {
+		return m.Biome
+	}
+	return nil
+}
+
 type SetCellMaterialRequest struct {
 	Planet               int64      `protobuf:"varint,1,opt,name=planet,proto3" json:"planet,omitempty"`
 	Index                *CellIndex `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
@@ -1227,6 +1452,343 @@ func (m *HitPlayerResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_HitPlayerResponse proto.InternalMessageInfo
 
+type BackupRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{21}
+}
+
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+type BackupResponse struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
+func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupResponse) ProtoMessage()    {}
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{22}
+}
+
+func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupResponse.Unmarshal(m, b)
+}
+func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupResponse.Merge(m, src)
+}
+func (m *BackupResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupResponse.Size(m)
+}
+func (m *BackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+
+func (m *BackupResponse) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type PlaceStructureRequest struct {
+	Planet               int64      `protobuf:"varint,1,opt,name=planet,proto3" json:"planet,omitempty"`
+	Origin               *CellIndex `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Turns                int64      `protobuf:"varint,3,opt,name=turns,proto3" json:"turns,omitempty"`
+	Voxels               []*Voxel   `protobuf:"bytes,4,rep,name=voxels,proto3" json:"voxels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PlaceStructureRequest) Reset()         { *m = PlaceStructureRequest{} }
+func (m *PlaceStructureRequest) String() string { return proto.CompactTextString(m) }
+func (*PlaceStructureRequest) ProtoMessage()    {}
+func (*PlaceStructureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{23}
+}
+
+func (m *PlaceStructureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlaceStructureRequest.Unmarshal(m, b)
+}
+func (m *PlaceStructureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlaceStructureRequest.Marshal(b, m, deterministic)
+}
+func (m *PlaceStructureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlaceStructureRequest.Merge(m, src)
+}
+func (m *PlaceStructureRequest) XXX_Size() int {
+	return xxx_messageInfo_PlaceStructureRequest.Size(m)
+}
+func (m *PlaceStructureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlaceStructureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlaceStructureRequest proto.InternalMessageInfo
+
+func (m *PlaceStructureRequest) GetPlanet() int64 {
+	if m != nil {
+		return m.Planet
+	}
+	return 0
+}
+
+func (m *PlaceStructureRequest) GetOrigin() *CellIndex {
+	if m != nil {
+		return m.Origin
+	}
+	return nil
+}
+
+func (m *PlaceStructureRequest) GetTurns() int64 {
+	if m != nil {
+		return m.Turns
+	}
+	return 0
+}
+
+func (m *PlaceStructureRequest) GetVoxels() []*Voxel {
+	if m != nil {
+		return m.Voxels
+	}
+	return nil
+}
+
+type Voxel struct {
+	X                    int64    `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y                    int64    `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z                    int64    `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"`
+	Material             Material `protobuf:"varint,4,opt,name=material,proto3,enum=govox.Material" json:"material,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Voxel) Reset()         { *m = Voxel{} }
+func (m *Voxel) String() string { return proto.CompactTextString(m) }
+func (*Voxel) ProtoMessage()    {}
+func (*Voxel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{24}
+}
+
+func (m *Voxel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Voxel.Unmarshal(m, b)
+}
+func (m *Voxel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Voxel.Marshal(b, m, deterministic)
+}
+func (m *Voxel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Voxel.Merge(m, src)
+}
+func (m *Voxel) XXX_Size() int {
+	return xxx_messageInfo_Voxel.Size(m)
+}
+func (m *Voxel) XXX_DiscardUnknown() {
+	xxx_messageInfo_Voxel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Voxel proto.InternalMessageInfo
+
+func (m *Voxel) GetX() int64 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *Voxel) GetY() int64 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+func (m *Voxel) GetZ() int64 {
+	if m != nil {
+		return m.Z
+	}
+	return 0
+}
+
+func (m *Voxel) GetMaterial() Material {
+	if m != nil {
+		return m.Material
+	}
+	return Material_AIR
+}
+
+type PlaceStructureResponse struct {
+	Changed              int64    `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlaceStructureResponse) Reset()         { *m = PlaceStructureResponse{} }
+func (m *PlaceStructureResponse) String() string { return proto.CompactTextString(m) }
+func (*PlaceStructureResponse) ProtoMessage()    {}
+func (*PlaceStructureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{25}
+}
+
+func (m *PlaceStructureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlaceStructureResponse.Unmarshal(m, b)
+}
+func (m *PlaceStructureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlaceStructureResponse.Marshal(b, m, deterministic)
+}
+func (m *PlaceStructureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlaceStructureResponse.Merge(m, src)
+}
+func (m *PlaceStructureResponse) XXX_Size() int {
+	return xxx_messageInfo_PlaceStructureResponse.Size(m)
+}
+func (m *PlaceStructureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlaceStructureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlaceStructureResponse proto.InternalMessageInfo
+
+func (m *PlaceStructureResponse) GetChanged() int64 {
+	if m != nil {
+		return m.Changed
+	}
+	return 0
+}
+
+type GetSunElevationRequest struct {
+	Planet               int64      `protobuf:"varint,1,opt,name=planet,proto3" json:"planet,omitempty"`
+	Index                *CellIndex `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	Time                 float64    `protobuf:"fixed64,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetSunElevationRequest) Reset()         { *m = GetSunElevationRequest{} }
+func (m *GetSunElevationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSunElevationRequest) ProtoMessage()    {}
+func (*GetSunElevationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{26}
+}
+
+func (m *GetSunElevationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSunElevationRequest.Unmarshal(m, b)
+}
+func (m *GetSunElevationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSunElevationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSunElevationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSunElevationRequest.Merge(m, src)
+}
+func (m *GetSunElevationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSunElevationRequest.Size(m)
+}
+func (m *GetSunElevationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSunElevationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSunElevationRequest proto.InternalMessageInfo
+
+func (m *GetSunElevationRequest) GetPlanet() int64 {
+	if m != nil {
+		return m.Planet
+	}
+	return 0
+}
+
+func (m *GetSunElevationRequest) GetIndex() *CellIndex {
+	if m != nil {
+		return m.Index
+	}
+	return nil
+}
+
+func (m *GetSunElevationRequest) GetTime() float64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type GetSunElevationResponse struct {
+	Elevation            float64  `protobuf:"fixed64,1,opt,name=elevation,proto3" json:"elevation,omitempty"`
+	HasSun               bool     `protobuf:"varint,2,opt,name=hasSun,proto3" json:"hasSun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSunElevationResponse) Reset()         { *m = GetSunElevationResponse{} }
+func (m *GetSunElevationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSunElevationResponse) ProtoMessage()    {}
+func (*GetSunElevationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_303e99b6bdde8eb4, []int{27}
+}
+
+func (m *GetSunElevationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSunElevationResponse.Unmarshal(m, b)
+}
+func (m *GetSunElevationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSunElevationResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSunElevationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSunElevationResponse.Merge(m, src)
+}
+func (m *GetSunElevationResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSunElevationResponse.Size(m)
+}
+func (m *GetSunElevationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSunElevationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSunElevationResponse proto.InternalMessageInfo
+
+func (m *GetSunElevationResponse) GetElevation() float64 {
+	if m != nil {
+		return m.Elevation
+	}
+	return 0
+}
+
+func (m *GetSunElevationResponse) GetHasSun() bool {
+	if m != nil {
+		return m.HasSun
+	}
+	return false
+}
+
 type CellMaterialRequest struct {
 	Index                *CellIndex  `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
 	Planet               *PlanetSpec `protobuf:"bytes,2,opt,name=planet,proto3" json:"planet,omitempty"`
@@ -1239,7 +1801,7 @@ func (m *CellMaterialRequest) Reset()         { *m = CellMaterialRequest{} }
 func (m *CellMaterialRequest) String() string { return proto.CompactTextString(m) }
 func (*CellMaterialRequest) ProtoMessage()    {}
 func (*CellMaterialRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_303e99b6bdde8eb4, []int{21}
+	return fileDescriptor_303e99b6bdde8eb4, []int{28}
 }
 
 func (m *CellMaterialRequest) XXX_Unmarshal(b []byte) error {
@@ -1285,7 +1847,7 @@ func (m *CellMaterialResponse) Reset()         { *m = CellMaterialResponse{} }
 func (m *CellMaterialResponse) String() string { return proto.CompactTextString(m) }
 func (*CellMaterialResponse) ProtoMessage()    {}
 func (*CellMaterialResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_303e99b6bdde8eb4, []int{22}
+	return fileDescriptor_303e99b6bdde8eb4, []int{29}
 }
 
 func (m *CellMaterialResponse) XXX_Unmarshal(b []byte) error {
@@ -1315,6 +1877,7 @@ func (m *CellMaterialResponse) GetCell() *Cell {
 
 func init() {
 	proto.RegisterEnum("govox.Material", Material_name, Material_value)
+	proto.RegisterEnum("govox.Biome", Biome_name, Biome_value)
 	proto.RegisterType((*GetPlanetsRequest)(nil), "govox.GetPlanetsRequest")
 	proto.RegisterType((*GetPlanetsResponse)(nil), "govox.GetPlanetsResponse")
 	proto.RegisterType((*PlanetSpec)(nil), "govox.PlanetSpec")
@@ -1329,6 +1892,7 @@ func init() {
 	proto.RegisterType((*PlanetGeometry)(nil), "govox.PlanetGeometry")
 	proto.RegisterType((*PlanetGeometry_AltitudeRow)(nil), "govox.PlanetGeometry.AltitudeRow")
 	proto.RegisterType((*PlanetGeometry_MaterialRow)(nil), "govox.PlanetGeometry.MaterialRow")
+	proto.RegisterType((*PlanetGeometry_BiomeRow)(nil), "govox.PlanetGeometry.BiomeRow")
 	proto.RegisterType((*SetCellMaterialRequest)(nil), "govox.SetCellMaterialRequest")
 	proto.RegisterType((*Cell)(nil), "govox.Cell")
 	proto.RegisterType((*CellIndex)(nil), "govox.CellIndex")
@@ -1340,6 +1904,13 @@ func init() {
 	proto.RegisterType((*UpdatePlayerStateResponse)(nil), "govox.UpdatePlayerStateResponse")
 	proto.RegisterType((*HitPlayerRequest)(nil), "govox.HitPlayerRequest")
 	proto.RegisterType((*HitPlayerResponse)(nil), "govox.HitPlayerResponse")
+	proto.RegisterType((*BackupRequest)(nil), "govox.BackupRequest")
+	proto.RegisterType((*BackupResponse)(nil), "govox.BackupResponse")
+	proto.RegisterType((*PlaceStructureRequest)(nil), "govox.PlaceStructureRequest")
+	proto.RegisterType((*Voxel)(nil), "govox.Voxel")
+	proto.RegisterType((*PlaceStructureResponse)(nil), "govox.PlaceStructureResponse")
+	proto.RegisterType((*GetSunElevationRequest)(nil), "govox.GetSunElevationRequest")
+	proto.RegisterType((*GetSunElevationResponse)(nil), "govox.GetSunElevationResponse")
 	proto.RegisterType((*CellMaterialRequest)(nil), "govox.CellMaterialRequest")
 	proto.RegisterType((*CellMaterialResponse)(nil), "govox.CellMaterialResponse")
 }
@@ -1363,6 +1934,9 @@ type GovoxClient interface {
 	SendText(ctx context.Context, in *SendTextRequest, opts ...grpc.CallOption) (*SendTextResponse, error)
 	UpdatePlayerState(ctx context.Context, in *UpdatePlayerStateRequest, opts ...grpc.CallOption) (*UpdatePlayerStateResponse, error)
 	HitPlayer(ctx context.Context, in *HitPlayerRequest, opts ...grpc.CallOption) (*HitPlayerResponse, error)
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
+	PlaceStructure(ctx context.Context, in *PlaceStructureRequest, opts ...grpc.CallOption) (*PlaceStructureResponse, error)
+	GetSunElevation(ctx context.Context, in *GetSunElevationRequest, opts ...grpc.CallOption) (*GetSunElevationResponse, error)
 }
 
 type govoxClient struct {
@@ -1436,6 +2010,33 @@ func (c *govoxClient) HitPlayer(ctx context.Context, in *HitPlayerRequest, opts
 	return out, nil
 }
 
+func (c *govoxClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, "/govox.Govox/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *govoxClient) PlaceStructure(ctx context.Context, in *PlaceStructureRequest, opts ...grpc.CallOption) (*PlaceStructureResponse, error) {
+	out := new(PlaceStructureResponse)
+	err := c.cc.Invoke(ctx, "/govox.Govox/PlaceStructure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *govoxClient) GetSunElevation(ctx context.Context, in *GetSunElevationRequest, opts ...grpc.CallOption) (*GetSunElevationResponse, error) {
+	out := new(GetSunElevationResponse)
+	err := c.cc.Invoke(ctx, "/govox.Govox/GetSunElevation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GovoxServer is the server API for Govox service.
 type GovoxServer interface {
 	GetPlanets(context.Context, *GetPlanetsRequest) (*GetPlanetsResponse, error)
@@ -1445,6 +2046,9 @@ type GovoxServer interface {
 	SendText(context.Context, *SendTextRequest) (*SendTextResponse, error)
 	UpdatePlayerState(context.Context, *UpdatePlayerStateRequest) (*UpdatePlayerStateResponse, error)
 	HitPlayer(context.Context, *HitPlayerRequest) (*HitPlayerResponse, error)
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
+	PlaceStructure(context.Context, *PlaceStructureRequest) (*PlaceStructureResponse, error)
+	GetSunElevation(context.Context, *GetSunElevationRequest) (*GetSunElevationResponse, error)
 }
 
 func RegisterGovoxServer(s *grpc.Server, srv GovoxServer) {
@@ -1577,6 +2181,60 @@ func _Govox_HitPlayer_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Govox_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GovoxServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/govox.Govox/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GovoxServer).Backup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Govox_PlaceStructure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceStructureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GovoxServer).PlaceStructure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/govox.Govox/PlaceStructure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GovoxServer).PlaceStructure(ctx, req.(*PlaceStructureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Govox_GetSunElevation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSunElevationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GovoxServer).GetSunElevation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/govox.Govox/GetSunElevation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GovoxServer).GetSunElevation(ctx, req.(*GetSunElevationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Govox_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "govox.Govox",
 	HandlerType: (*GovoxServer)(nil),
@@ -1609,6 +2267,18 @@ var _Govox_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HitPlayer",
 			Handler:    _Govox_HitPlayer_Handler,
 		},
+		{
+			MethodName: "Backup",
+			Handler:    _Govox_Backup_Handler,
+		},
+		{
+			MethodName: "PlaceStructure",
+			Handler:    _Govox_PlaceStructure_Handler,
+		},
+		{
+			MethodName: "GetSunElevation",
+			Handler:    _Govox_GetSunElevation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "govox.proto",
@@ -1681,74 +2351,111 @@ var _Generator_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("govox.proto", fileDescriptor_303e99b6bdde8eb4) }
 
 var fileDescriptor_303e99b6bdde8eb4 = []byte{
-	// 1090 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x6f, 0x6f, 0xdb, 0x36,
-	0x13, 0x8f, 0xfc, 0x27, 0xb6, 0xcf, 0x8e, 0xad, 0xb2, 0x7d, 0x52, 0x45, 0x7d, 0xb6, 0x7a, 0xc2,
-	0xd6, 0xb9, 0x2d, 0x10, 0x60, 0x0e, 0xb0, 0x01, 0x03, 0x86, 0xcd, 0x89, 0x3d, 0x2f, 0x98, 0x1b,
-	0x07, 0x94, 0xd3, 0x6e, 0xaf, 0x06, 0xd6, 0xe2, 0x5c, 0xa1, 0x8a, 0xe8, 0x49, 0xcc, 0x9a, 0x7c,
-	0x8e, 0xed, 0xa3, 0xec, 0xd5, 0xbe, 0xcd, 0xbe, 0xc9, 0x40, 0x8a, 0xa2, 0x69, 0x5b, 0x49, 0x80,
-	0xbd, 0xe3, 0xfd, 0xee, 0x77, 0xc7, 0xe3, 0xf1, 0xee, 0x48, 0x68, 0x2e, 0xd8, 0xef, 0xec, 0xfa,
-	0x70, 0x99, 0x30, 0xce, 0x50, 0x55, 0x0a, 0xde, 0x43, 0x78, 0x30, 0xa6, 0xfc, 0x3c, 0x22, 0x31,
-	0xe5, 0x29, 0xa6, 0xbf, 0x5d, 0xd1, 0x94, 0x7b, 0x03, 0x40, 0x26, 0x98, 0x2e, 0x59, 0x9c, 0x52,
-	0xf4, 0x12, 0x6a, 0xcb, 0x0c, 0x72, 0xac, 0x6e, 0xb9, 0xd7, 0xec, 0x3f, 0x38, 0xcc, 0x1c, 0x66,
-	0x44, 0x7f, 0x49, 0xe7, 0x38, 0x67, 0x78, 0x7f, 0x95, 0x00, 0x56, 0x38, 0x6a, 0x43, 0x29, 0x0c,
-	0x1c, 0xab, 0x6b, 0xf5, 0xca, 0xb8, 0x14, 0x06, 0x08, 0x41, 0x25, 0x26, 0x97, 0xd4, 0x29, 0x75,
-	0xad, 0x5e, 0x03, 0xcb, 0x35, 0xda, 0x87, 0xdd, 0x84, 0x04, 0xe1, 0x55, 0xea, 0x94, 0xbb, 0x56,
-	0xcf, 0xc2, 0x4a, 0x42, 0x2e, 0xd4, 0x49, 0xc4, 0x4f, 0x68, 0x14, 0xa5, 0x4e, 0x45, 0x7a, 0xd0,
-	0x32, 0xea, 0x42, 0x93, 0x25, 0x6f, 0x43, 0x15, 0xab, 0x53, 0x95, 0x6a, 0x13, 0x42, 0x9f, 0xc2,
-	0x9e, 0x14, 0x87, 0x61, 0xca, 0x49, 0x3c, 0xa7, 0xce, 0xae, 0x74, 0xbe, 0x0e, 0x22, 0x0f, 0x5a,
-	0x12, 0xf0, 0xe9, 0x9c, 0xc5, 0x41, 0xea, 0xd4, 0x24, 0x69, 0x0d, 0x43, 0x3d, 0xe8, 0x24, 0x8c,
-	0x13, 0x1e, 0xb2, 0x38, 0xa7, 0xd5, 0x25, 0x6d, 0x13, 0x16, 0xa7, 0x4b, 0x29, 0x0d, 0x9c, 0x86,
-	0x0c, 0x47, 0xae, 0x45, 0x1c, 0x0b, 0x1a, 0xd3, 0x84, 0x70, 0x96, 0xcc, 0x6e, 0x96, 0xd4, 0x01,
-	0x79, 0xf4, 0x75, 0xd0, 0xc3, 0xd0, 0x19, 0x53, 0x7e, 0xf2, 0xee, 0x2a, 0x7e, 0xaf, 0x2e, 0x43,
-	0xa4, 0x25, 0x4b, 0xaa, 0x4a, 0x9f, 0x92, 0xd0, 0xe7, 0x50, 0x0d, 0xe3, 0x80, 0x5e, 0xcb, 0x1c,
-	0xae, 0x2e, 0x43, 0xda, 0x9e, 0x0a, 0x05, 0xce, 0xf4, 0xde, 0x31, 0xc0, 0x0a, 0x44, 0x36, 0x94,
-	0x23, 0x92, 0xfb, 0x12, 0x4b, 0x89, 0xb0, 0x58, 0xba, 0x11, 0x08, 0x8b, 0x05, 0x42, 0x22, 0x2e,
-	0xaf, 0xa1, 0x8c, 0xc5, 0xd2, 0xfb, 0x12, 0xec, 0x55, 0x5c, 0xaa, 0x1e, 0x3c, 0xa8, 0xce, 0x05,
-	0x20, 0x7d, 0x35, 0xfb, 0x2d, 0x33, 0x00, 0x9c, 0xa9, 0xbc, 0xbf, 0x2d, 0xa8, 0x4a, 0x00, 0xf5,
-	0xa0, 0x32, 0xa7, 0x51, 0xa4, 0x4a, 0xe7, 0x91, 0x49, 0x3e, 0x14, 0x77, 0x39, 0x21, 0x1c, 0x4b,
-	0x06, 0x7a, 0x06, 0xed, 0x0f, 0x24, 0xe4, 0x61, 0xbc, 0xf8, 0x9e, 0x25, 0x43, 0xc2, 0x89, 0x0c,
-	0xad, 0x8e, 0x37, 0x50, 0xf7, 0x08, 0x6a, 0xca, 0xf0, 0x5e, 0xe7, 0x83, 0x48, 0x39, 0x77, 0x5f,
-	0x64, 0x46, 0x83, 0x88, 0xa3, 0xa7, 0x6b, 0x46, 0xcd, 0xdc, 0x88, 0x46, 0x51, 0xc6, 0xf5, 0xfa,
-	0xe0, 0xe8, 0x36, 0x18, 0x53, 0x76, 0x49, 0x79, 0x72, 0x73, 0xcf, 0xad, 0x78, 0x67, 0x70, 0x50,
-	0x60, 0xa3, 0x32, 0xf6, 0x05, 0xd4, 0x17, 0x0a, 0x53, 0x49, 0xfb, 0xdf, 0x5a, 0x0b, 0x69, 0x03,
-	0x4d, 0xf3, 0xfe, 0x28, 0x41, 0x7b, 0x5d, 0x89, 0xbe, 0x91, 0xfd, 0x10, 0xf2, 0xab, 0x80, 0xaa,
-	0xd8, 0x3f, 0x29, 0xf4, 0x72, 0x38, 0x50, 0x2c, 0xcc, 0x3e, 0x60, 0x6d, 0x22, 0xcc, 0x2f, 0x09,
-	0xa7, 0x49, 0x48, 0x22, 0xa7, 0x74, 0x97, 0xf9, 0x2b, 0xc5, 0x92, 0xe6, 0xb9, 0x09, 0xfa, 0x3f,
-	0x34, 0xc2, 0x74, 0xc2, 0x48, 0x10, 0xc6, 0x0b, 0x59, 0x21, 0x75, 0xbc, 0x02, 0xdc, 0xe7, 0xd0,
-	0x34, 0x76, 0x55, 0xad, 0xbb, 0x0a, 0xb5, 0xbc, 0x8a, 0xc3, 0xfd, 0x1a, 0x9a, 0xc6, 0x0e, 0xe8,
-	0xa5, 0x11, 0x96, 0xa0, 0xb6, 0xfb, 0x1d, 0x15, 0x96, 0x66, 0x69, 0x82, 0x77, 0x03, 0xfb, 0x3e,
-	0x95, 0x23, 0x40, 0x2b, 0xef, 0xe9, 0x96, 0x67, 0xeb, 0xdd, 0x62, 0x1b, 0xb7, 0x6d, 0x36, 0x8b,
-	0x2e, 0x8a, 0xb2, 0xa4, 0x15, 0x14, 0xc5, 0x11, 0x54, 0x84, 0xb4, 0x11, 0xaf, 0x75, 0x77, 0xbc,
-	0x03, 0x68, 0xe8, 0x9d, 0xfe, 0x63, 0x07, 0x7e, 0xab, 0xaa, 0x9d, 0xcd, 0x4d, 0x07, 0xd6, 0x96,
-	0x03, 0x6b, 0xcb, 0x81, 0x95, 0x39, 0x38, 0x80, 0xc7, 0x5b, 0x39, 0xcb, 0xea, 0xd2, 0xfb, 0x0c,
-	0x3a, 0x3e, 0x8d, 0x83, 0x19, 0xbd, 0xe6, 0x79, 0x1e, 0x11, 0x54, 0x38, 0xbd, 0xce, 0x36, 0x69,
-	0x60, 0xb9, 0xf6, 0x10, 0xd8, 0x2b, 0x9a, 0x32, 0x0d, 0xc0, 0xb9, 0x58, 0x06, 0x84, 0xd3, 0xf3,
-	0x88, 0xdc, 0xd0, 0xc4, 0xe7, 0x84, 0x53, 0xc3, 0x87, 0x1c, 0xf2, 0x96, 0x31, 0xe4, 0x5d, 0xa8,
-	0x2f, 0x59, 0x1a, 0x8a, 0x69, 0x29, 0xab, 0xcf, 0xc2, 0x5a, 0x46, 0x0e, 0xd4, 0x22, 0xc6, 0xde,
-	0x0f, 0xc3, 0xc4, 0x29, 0x4b, 0x55, 0x2e, 0x7a, 0x4f, 0xe0, 0xa0, 0x60, 0x17, 0x15, 0xc2, 0x6b,
-	0xb0, 0x7f, 0x90, 0xe3, 0xfe, 0x86, 0x26, 0xc6, 0xd6, 0xbf, 0x26, 0xec, 0x32, 0xdf, 0x5a, 0xac,
-	0x45, 0x69, 0x70, 0x92, 0x2c, 0x28, 0x57, 0xaf, 0x8e, 0x92, 0x04, 0x4e, 0x2e, 0xd9, 0x55, 0x9c,
-	0xa7, 0x5b, 0x49, 0xe2, 0x69, 0x34, 0xfc, 0xaa, 0xcd, 0xde, 0xc1, 0xc3, 0xa2, 0xb2, 0xd3, 0xe5,
-	0x65, 0xdd, 0x5d, 0x5e, 0xcf, 0x75, 0x79, 0xae, 0x4f, 0x6d, 0xe3, 0x09, 0xcd, 0x27, 0xc9, 0x57,
-	0xf0, 0xa8, 0xe8, 0xb2, 0x8c, 0xb1, 0x55, 0x5c, 0xa1, 0x2f, 0xfe, 0xb1, 0xa0, 0x9e, 0x5b, 0xa1,
-	0x1a, 0x94, 0x07, 0xa7, 0xd8, 0xde, 0x41, 0x0d, 0xa8, 0x8e, 0xf1, 0xc0, 0xf7, 0x6d, 0x0b, 0xd5,
-	0xa1, 0x32, 0x3c, 0xc5, 0x33, 0xbb, 0x24, 0x40, 0x7f, 0x36, 0x3d, 0x1b, 0xd9, 0x65, 0x01, 0xbe,
-	0x9a, 0x4e, 0xcf, 0xec, 0x0a, 0x6a, 0x41, 0x7d, 0xe0, 0xcf, 0x46, 0x78, 0x7a, 0x3a, 0xb4, 0xab,
-	0xc2, 0x81, 0x7f, 0x71, 0x66, 0xef, 0xa2, 0x36, 0xc0, 0xf1, 0xe4, 0x62, 0xf4, 0xcb, 0xf1, 0x64,
-	0x7a, 0xf2, 0xa3, 0x5d, 0x43, 0x7b, 0xd0, 0x90, 0xb2, 0x3f, 0x38, 0x1b, 0xda, 0x75, 0x64, 0x43,
-	0xeb, 0xfc, 0x02, 0x9f, 0x4f, 0x72, 0x42, 0x03, 0x75, 0xa0, 0xa9, 0x10, 0x49, 0x01, 0x61, 0x81,
-	0x47, 0x43, 0xa5, 0x6f, 0x8a, 0x7d, 0x84, 0x28, 0x95, 0x2d, 0x61, 0xff, 0xf3, 0x68, 0x32, 0x99,
-	0xbe, 0x51, 0xfa, 0x3d, 0x61, 0xaf, 0x10, 0x49, 0x69, 0x8b, 0x68, 0xdf, 0x0c, 0x66, 0x23, 0x6c,
-	0x77, 0xfa, 0x7f, 0x56, 0xa0, 0x3a, 0x16, 0x07, 0x47, 0x27, 0x00, 0xab, 0xbf, 0x0a, 0x72, 0x54,
-	0x3a, 0xb6, 0xfe, 0x34, 0xee, 0x41, 0x81, 0x46, 0xdd, 0xe9, 0x8e, 0x98, 0x89, 0xf9, 0xf3, 0x86,
-	0xf6, 0x57, 0x44, 0xf3, 0x1d, 0x76, 0x1f, 0x6f, 0xe1, 0xda, 0xfc, 0x27, 0xe3, 0x13, 0xa5, 0xc7,
-	0xf4, 0xd3, 0xcd, 0x0d, 0x37, 0x9e, 0x10, 0xb7, 0x7b, 0x3b, 0x41, 0x7b, 0xc6, 0xa2, 0x33, 0xd7,
-	0x9a, 0x16, 0x7d, 0xa4, 0xcc, 0x8a, 0x07, 0xa0, 0xfb, 0xf1, 0x6d, 0x6a, 0xf3, 0xb0, 0x79, 0x1b,
-	0xeb, 0xc3, 0x6e, 0xb4, 0xbf, 0x3e, 0xec, 0x56, 0xbf, 0xcb, 0xc3, 0x6e, 0xf5, 0xa2, 0x3e, 0xec,
-	0x6d, 0xb3, 0x40, 0x1f, 0xf6, 0xf6, 0x36, 0xde, 0x41, 0xdf, 0x41, 0x43, 0x37, 0x1c, 0xca, 0x23,
-	0xd8, 0x6c, 0x6d, 0xd7, 0xd9, 0x56, 0xe4, 0x1e, 0xfa, 0xaf, 0xa1, 0x31, 0xce, 0xff, 0x53, 0xe8,
-	0x14, 0x5a, 0x6b, 0x89, 0x73, 0x8d, 0x56, 0xd9, 0xcc, 0xda, 0x93, 0x42, 0x5d, 0xee, 0xf7, 0xed,
-	0xae, 0xfc, 0x33, 0x1f, 0xfd, 0x1b, 0x00, 0x00, 0xff, 0xff, 0xe6, 0x6c, 0x6d, 0x44, 0x42, 0x0b,
-	0x00, 0x00,
+	// 1691 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdd, 0x6f, 0xdb, 0xc8,
+	0x11, 0x3f, 0xea, 0xcb, 0xd2, 0xc8, 0x96, 0xe8, 0x8d, 0x3f, 0x18, 0x5e, 0x2e, 0xe7, 0x12, 0xe9,
+	0xd5, 0x97, 0x2b, 0x0c, 0xd4, 0x29, 0x7a, 0x40, 0x81, 0xa2, 0xa5, 0x2d, 0x9e, 0x62, 0x54, 0x11,
+	0x8d, 0xa5, 0x9c, 0x34, 0x2f, 0x0d, 0x36, 0xd4, 0x46, 0x26, 0x42, 0x91, 0x2a, 0xb9, 0x4a, 0xac,
+	0xbc, 0xf5, 0x8f, 0xe8, 0x7f, 0xd2, 0xbf, 0xab, 0x0f, 0x7d, 0xeb, 0x5b, 0x31, 0xcb, 0x25, 0x45,
+	0x7d, 0x38, 0x41, 0x8b, 0x7b, 0xdb, 0xf9, 0xcd, 0xf7, 0x72, 0x66, 0x67, 0x24, 0x68, 0x4f, 0xe2,
+	0x0f, 0xf1, 0xdd, 0xd9, 0x2c, 0x89, 0x45, 0x4c, 0xea, 0x92, 0xb0, 0x1e, 0xc0, 0x7e, 0x9f, 0x8b,
+	0xeb, 0x90, 0x45, 0x5c, 0xa4, 0x94, 0xff, 0x6d, 0xce, 0x53, 0x61, 0xd9, 0x40, 0xca, 0x60, 0x3a,
+	0x8b, 0xa3, 0x94, 0x93, 0x1f, 0x60, 0x67, 0x96, 0x41, 0x86, 0x76, 0x52, 0x3d, 0x6d, 0x9f, 0xef,
+	0x9f, 0x65, 0x06, 0x33, 0x41, 0x6f, 0xc6, 0x7d, 0x9a, 0x4b, 0x58, 0xff, 0xac, 0x03, 0x2c, 0x71,
+	0xd2, 0x81, 0x4a, 0x30, 0x36, 0xb4, 0x13, 0xed, 0xb4, 0x4a, 0x2b, 0xc1, 0x98, 0x10, 0xa8, 0x45,
+	0x6c, 0xca, 0x8d, 0xca, 0x89, 0x76, 0xda, 0xa2, 0xf2, 0x4c, 0x8e, 0xa0, 0x91, 0xb0, 0x71, 0x30,
+	0x4f, 0x8d, 0xea, 0x89, 0x76, 0xaa, 0x51, 0x45, 0x11, 0x13, 0x9a, 0x2c, 0x14, 0x97, 0x3c, 0x0c,
+	0x53, 0xa3, 0x26, 0x2d, 0x14, 0x34, 0x39, 0x81, 0x76, 0x9c, 0xbc, 0x0d, 0x54, 0xac, 0x46, 0x5d,
+	0xb2, 0xcb, 0x10, 0x79, 0x02, 0x7b, 0x92, 0xec, 0x05, 0xa9, 0x60, 0x91, 0xcf, 0x8d, 0x86, 0x34,
+	0xbe, 0x0a, 0x12, 0x0b, 0x76, 0x25, 0xe0, 0x71, 0x3f, 0x8e, 0xc6, 0xa9, 0xb1, 0x23, 0x85, 0x56,
+	0x30, 0x72, 0x0a, 0xdd, 0x24, 0x16, 0x4c, 0x04, 0x71, 0x94, 0x8b, 0x35, 0xa5, 0xd8, 0x3a, 0x8c,
+	0xd9, 0xa5, 0x9c, 0x8f, 0x8d, 0x96, 0x0c, 0x47, 0x9e, 0x31, 0x8e, 0x09, 0x8f, 0x78, 0xc2, 0x44,
+	0x9c, 0x8c, 0x16, 0x33, 0x6e, 0x80, 0x4c, 0x7d, 0x15, 0xc4, 0x38, 0xb8, 0xef, 0xf3, 0x48, 0x24,
+	0x81, 0x1f, 0x88, 0x85, 0xd1, 0xce, 0xe2, 0x28, 0x63, 0x98, 0x73, 0x10, 0xf9, 0x61, 0x10, 0x49,
+	0x9f, 0xc6, 0xae, 0x14, 0x29, 0x43, 0xe8, 0x8b, 0xa5, 0x3e, 0x8f, 0xc6, 0x41, 0x34, 0x19, 0xc6,
+	0x63, 0x6e, 0xec, 0x65, 0x39, 0xaf, 0x80, 0xe4, 0x31, 0x40, 0x76, 0x51, 0xb7, 0x2c, 0xe5, 0x46,
+	0x47, 0x8a, 0x94, 0x10, 0xf2, 0x08, 0x5a, 0xec, 0x2e, 0x60, 0xe1, 0x28, 0x08, 0x85, 0xd1, 0x95,
+	0xec, 0x25, 0x80, 0x3e, 0x44, 0x10, 0x8a, 0x5e, 0x90, 0x70, 0x5f, 0xc6, 0xa1, 0x67, 0x3e, 0x56,
+	0x40, 0xf2, 0x1d, 0x74, 0xd2, 0x79, 0xf2, 0x8e, 0xf9, 0xbc, 0x9f, 0xb0, 0x0f, 0x98, 0xd1, 0xbe,
+	0x14, 0x5b, 0x43, 0xc9, 0xaf, 0x61, 0x9f, 0x89, 0x69, 0x9c, 0xce, 0x6e, 0x79, 0xc2, 0x7b, 0x3c,
+	0x4a, 0x51, 0x94, 0x48, 0xd1, 0x4d, 0x06, 0x56, 0x84, 0x88, 0x67, 0x71, 0x18, 0x4f, 0x16, 0xc6,
+	0x03, 0x79, 0x8d, 0x05, 0x8d, 0x51, 0xfb, 0xb7, 0xf3, 0xe8, 0xbd, 0x17, 0x7c, 0xe2, 0xc6, 0x81,
+	0xfc, 0x00, 0x4b, 0x00, 0x73, 0xf6, 0x79, 0x18, 0x3e, 0xe7, 0xc1, 0xe4, 0x56, 0x18, 0x87, 0x59,
+	0xce, 0x4b, 0xc4, 0xa2, 0xd0, 0xed, 0x73, 0x71, 0x89, 0xf2, 0xaa, 0x19, 0xb0, 0x2c, 0xb3, 0xa2,
+	0x56, 0xe5, 0xab, 0x28, 0xf2, 0x2b, 0xa8, 0x07, 0xd1, 0x98, 0xdf, 0xc9, 0x1a, 0x5e, 0x36, 0x83,
+	0xd4, 0xbd, 0x42, 0x06, 0xcd, 0xf8, 0xd6, 0x05, 0xc0, 0x12, 0x24, 0x3a, 0x54, 0x43, 0x96, 0xdb,
+	0xc2, 0xa3, 0x44, 0xe2, 0xc8, 0xa8, 0x28, 0x24, 0x8e, 0x10, 0x61, 0xa1, 0x90, 0x6d, 0x50, 0xa5,
+	0x78, 0xb4, 0x7e, 0x07, 0xfa, 0x32, 0x2e, 0xd5, 0x8f, 0x16, 0xd4, 0x65, 0x62, 0xd2, 0x56, 0xfb,
+	0x7c, 0xb7, 0x1c, 0x00, 0xcd, 0x58, 0xd6, 0xdf, 0x2b, 0x50, 0x97, 0x00, 0x39, 0x85, 0x1a, 0xe6,
+	0xa9, 0x5a, 0xf7, 0xa0, 0x2c, 0x7c, 0x86, 0xbd, 0x34, 0x60, 0x82, 0x4a, 0x09, 0xfc, 0x66, 0x1f,
+	0x59, 0x20, 0x82, 0x68, 0xf2, 0x53, 0x9c, 0xf4, 0x98, 0x60, 0x32, 0xb4, 0x26, 0x5d, 0x43, 0x89,
+	0x01, 0x3b, 0xf3, 0x28, 0x78, 0x17, 0x27, 0x53, 0x19, 0x69, 0x93, 0xe6, 0x24, 0xf9, 0x01, 0x9a,
+	0x53, 0x26, 0x78, 0x12, 0xb0, 0x50, 0x76, 0x6c, 0xe7, 0xbc, 0xab, 0xfc, 0xbd, 0x50, 0x30, 0x2d,
+	0x04, 0xcc, 0x67, 0xb0, 0xa3, 0xfc, 0x7f, 0x31, 0x46, 0x3b, 0x54, 0x31, 0x9a, 0x4f, 0x33, 0x25,
+	0x3b, 0x14, 0xe4, 0xdb, 0x15, 0xa5, 0x76, 0xae, 0xc4, 0xc3, 0x30, 0x93, 0xb5, 0xce, 0xc1, 0x28,
+	0x5e, 0xb3, 0x3e, 0x8f, 0xa7, 0x5c, 0x24, 0x8b, 0x2f, 0x7c, 0x5c, 0x6b, 0x08, 0x0f, 0xb7, 0xe8,
+	0xa8, 0x8b, 0xff, 0x0d, 0x34, 0x27, 0x0a, 0x53, 0x77, 0x7f, 0xb8, 0xf2, 0x12, 0x16, 0x0a, 0x85,
+	0x98, 0xf5, 0x9f, 0x0a, 0x74, 0x56, 0x99, 0xe4, 0x0f, 0xf2, 0x59, 0x0b, 0xc4, 0x7c, 0xcc, 0x55,
+	0xec, 0xbf, 0xd8, 0x6a, 0xe5, 0xcc, 0x56, 0x52, 0x34, 0xfe, 0x48, 0x0b, 0x15, 0x54, 0x2f, 0xee,
+	0xb8, 0xf2, 0x39, 0xf5, 0xe2, 0xca, 0x51, 0x3d, 0x57, 0xc1, 0x36, 0x09, 0xd2, 0x41, 0xcc, 0xf0,
+	0x35, 0x50, 0x9f, 0x6f, 0x09, 0x90, 0xdf, 0x42, 0xfd, 0x6d, 0x10, 0x4f, 0xb9, 0x51, 0x93, 0x96,
+	0x1f, 0x6f, 0xb7, 0x7c, 0x81, 0x22, 0x68, 0x36, 0x13, 0x36, 0xbf, 0x87, 0x76, 0x29, 0x56, 0xf5,
+	0x6e, 0x2f, 0x13, 0xac, 0x2e, 0xa3, 0x37, 0x7f, 0x0f, 0xed, 0x52, 0x5c, 0x2b, 0x05, 0x83, 0xa2,
+	0x9f, 0x2d, 0x98, 0x33, 0x68, 0xe6, 0x9e, 0xb1, 0x07, 0xb2, 0x40, 0x33, 0xad, 0xbc, 0x07, 0x32,
+	0x7e, 0xc6, 0xb2, 0x16, 0x70, 0xe4, 0x71, 0x39, 0x2f, 0x0a, 0x63, 0x5f, 0x68, 0xed, 0xef, 0x56,
+	0x5b, 0x5b, 0x2f, 0xd5, 0x54, 0xb9, 0xb3, 0x8b, 0xd2, 0xab, 0x9e, 0x68, 0xdb, 0x4b, 0xef, 0x19,
+	0xd4, 0x90, 0x5a, 0xcb, 0xef, 0xf3, 0x0d, 0x61, 0xd9, 0xd0, 0x2a, 0x3c, 0xfd, 0x9f, 0xcf, 0xc5,
+	0x1f, 0x55, 0x4f, 0xc5, 0x7e, 0xd9, 0x80, 0xb6, 0x61, 0x40, 0xdb, 0x30, 0xa0, 0x65, 0x06, 0x1e,
+	0xc2, 0xf1, 0xc6, 0x9d, 0x65, 0xd5, 0x6f, 0xfd, 0x12, 0xba, 0x1e, 0x8f, 0xc6, 0x23, 0x7e, 0x27,
+	0xf2, 0x7b, 0x24, 0x50, 0x13, 0xfc, 0x2e, 0x73, 0xd2, 0xa2, 0xf2, 0x6c, 0x11, 0xd0, 0x97, 0x62,
+	0x4a, 0x75, 0x0c, 0xc6, 0xcd, 0x6c, 0xcc, 0x04, 0xbf, 0x0e, 0xd9, 0x82, 0x27, 0x9e, 0x60, 0x82,
+	0x97, 0x6c, 0xc8, 0x8d, 0x40, 0x2b, 0x6d, 0x04, 0x26, 0x34, 0x67, 0x71, 0x1a, 0xc8, 0xf1, 0x82,
+	0x35, 0xae, 0xd1, 0x82, 0xc6, 0xd7, 0x27, 0x8c, 0xe3, 0xf7, 0xbd, 0x20, 0x31, 0xaa, 0x92, 0x95,
+	0x93, 0xd6, 0xd7, 0xf0, 0x70, 0x8b, 0x17, 0x15, 0xc2, 0x4b, 0xd0, 0x9f, 0xcb, 0xdd, 0x60, 0xc1,
+	0x93, 0x92, 0xeb, 0x77, 0x49, 0x3c, 0xcd, 0x5d, 0xe3, 0x19, 0x4b, 0x43, 0xb0, 0x64, 0xc2, 0x85,
+	0x5a, 0x51, 0x14, 0x85, 0x38, 0x9b, 0xc6, 0xf3, 0x28, 0xbf, 0x6e, 0x45, 0xe1, 0x1e, 0x55, 0xb2,
+	0xab, 0x9c, 0x75, 0x61, 0xef, 0x82, 0xf9, 0xef, 0xe7, 0xb3, 0x7c, 0xb1, 0x7a, 0x02, 0x9d, 0x1c,
+	0xc8, 0x44, 0xd0, 0xf7, 0x8c, 0x89, 0xdb, 0xdc, 0x37, 0x9e, 0xad, 0x7f, 0x68, 0x70, 0x78, 0x1d,
+	0x32, 0x9f, 0x7b, 0x22, 0x99, 0xfb, 0x62, 0x9e, 0xf0, 0x2f, 0x15, 0xec, 0x29, 0x34, 0xe2, 0x24,
+	0x98, 0x04, 0xd1, 0xbd, 0x15, 0xab, 0xf8, 0xe4, 0x00, 0xea, 0x62, 0x9e, 0x44, 0xa9, 0x0a, 0x3f,
+	0x23, 0xc8, 0x13, 0x68, 0x7c, 0x88, 0xef, 0x78, 0x98, 0xaa, 0x86, 0xcf, 0xfb, 0xe8, 0x25, 0x82,
+	0x54, 0xf1, 0xac, 0xbf, 0x42, 0x5d, 0x02, 0x64, 0x17, 0xb4, 0x3b, 0x15, 0x81, 0x76, 0x87, 0xd4,
+	0x42, 0x95, 0xa3, 0xb6, 0x40, 0xea, 0x93, 0x32, 0xae, 0x7d, 0xfa, 0x9f, 0x26, 0x81, 0x75, 0x0e,
+	0x47, 0xeb, 0x69, 0xab, 0x5b, 0x32, 0x60, 0xc7, 0xbf, 0x65, 0xd1, 0x84, 0xe7, 0x3b, 0x64, 0x4e,
+	0x5a, 0x21, 0x1c, 0xf5, 0xb9, 0xf0, 0xe6, 0x91, 0x13, 0xf2, 0x0f, 0x72, 0xfb, 0xf9, 0xb9, 0x9a,
+	0x1b, 0x8b, 0x3a, 0x98, 0x72, 0xd5, 0x15, 0xf2, 0x6c, 0xb9, 0x70, 0xbc, 0xe1, 0x4d, 0x85, 0xf8,
+	0x08, 0x5a, 0x3c, 0x07, 0x55, 0xb7, 0x2d, 0x01, 0x0c, 0xe6, 0x96, 0xa5, 0xde, 0x3c, 0x52, 0xb3,
+	0x54, 0x51, 0xd6, 0x2d, 0x3c, 0xd8, 0xf6, 0x30, 0x15, 0x31, 0x6a, 0x9f, 0x8f, 0xf1, 0xfb, 0x22,
+	0xc7, 0xd5, 0x25, 0xa4, 0xb4, 0x91, 0xe7, 0x13, 0xed, 0x47, 0x38, 0xd8, 0xd6, 0xce, 0xa5, 0xf1,
+	0xb9, 0xfd, 0x0d, 0x7b, 0xfa, 0xaf, 0x0a, 0x34, 0x73, 0x2d, 0xb2, 0x03, 0x55, 0xfb, 0x8a, 0xea,
+	0x5f, 0x91, 0x16, 0xd4, 0xfb, 0xd4, 0xf6, 0x3c, 0x5d, 0x23, 0x4d, 0xa8, 0xf5, 0xae, 0xe8, 0x48,
+	0xaf, 0x20, 0xe8, 0x8d, 0xdc, 0xa1, 0xa3, 0x57, 0x11, 0x7c, 0xe1, 0xba, 0x43, 0xbd, 0x46, 0x76,
+	0xa1, 0x69, 0x7b, 0x23, 0x87, 0xba, 0x57, 0x3d, 0xbd, 0x8e, 0x06, 0xbc, 0x9b, 0xa1, 0xde, 0x20,
+	0x1d, 0x80, 0x8b, 0xc1, 0x8d, 0xf3, 0xe6, 0x62, 0xe0, 0x5e, 0xfe, 0x59, 0xdf, 0x21, 0x7b, 0xd0,
+	0x92, 0xb4, 0x67, 0x0f, 0x7b, 0x7a, 0x93, 0xe8, 0xb0, 0x7b, 0x7d, 0x43, 0xaf, 0x07, 0xb9, 0x40,
+	0x8b, 0x74, 0xa1, 0xad, 0x10, 0x29, 0x02, 0xa8, 0x41, 0x9d, 0x9e, 0xe2, 0xb7, 0xd1, 0x0f, 0x92,
+	0x92, 0xb9, 0x8b, 0xfa, 0xaf, 0x9d, 0xc1, 0xc0, 0x7d, 0xa5, 0xf8, 0x7b, 0xa8, 0xaf, 0x10, 0x29,
+	0xd2, 0xc1, 0x68, 0x5f, 0xd9, 0x23, 0x87, 0xea, 0xdd, 0xc2, 0xf9, 0x2b, 0xd7, 0xed, 0xe9, 0x3a,
+	0xc6, 0xd6, 0xa7, 0x8e, 0x33, 0xcc, 0xe8, 0xfd, 0x92, 0x6b, 0x09, 0x90, 0x92, 0x2d, 0x09, 0x3c,
+	0x40, 0x40, 0x1a, 0x18, 0x38, 0xf6, 0x4b, 0xc7, 0xd3, 0x0f, 0x30, 0x9a, 0x4b, 0xd7, 0x1e, 0xbc,
+	0x71, 0xa9, 0xa3, 0x1f, 0x22, 0x75, 0x45, 0xdd, 0xa1, 0xa4, 0x8e, 0x90, 0xea, 0xbb, 0x83, 0x9e,
+	0xa4, 0x8e, 0x51, 0xf5, 0x92, 0xbe, 0xf6, 0x46, 0x4a, 0xd8, 0x78, 0xfa, 0x11, 0xea, 0x72, 0x7e,
+	0xa1, 0xdc, 0xd0, 0x7d, 0x73, 0x71, 0xe5, 0xbe, 0x70, 0xb2, 0x1b, 0x77, 0x2f, 0x1d, 0x7b, 0xa8,
+	0x6b, 0x78, 0xbc, 0x70, 0xec, 0xcb, 0xe7, 0x7a, 0x05, 0x23, 0x97, 0xdf, 0x61, 0x80, 0x39, 0x55,
+	0x09, 0x40, 0xe3, 0x27, 0x97, 0x3a, 0xde, 0x48, 0xaf, 0xe1, 0xb9, 0xe7, 0x78, 0x0e, 0x1d, 0xe9,
+	0x75, 0x34, 0x75, 0x61, 0xf7, 0x50, 0xc8, 0xd3, 0x1b, 0xc8, 0x19, 0xdd, 0x0c, 0x7b, 0xd4, 0xd6,
+	0x77, 0xd0, 0xd6, 0xb5, 0x3b, 0xb0, 0xa9, 0xde, 0x3c, 0xff, 0x77, 0x1d, 0xea, 0x7d, 0xfc, 0xfc,
+	0xe4, 0x12, 0x60, 0xf9, 0x03, 0x90, 0x18, 0xaa, 0x28, 0x36, 0x7e, 0x28, 0x9a, 0x0f, 0xb7, 0x70,
+	0xd4, 0xdb, 0xf7, 0x15, 0x6e, 0x28, 0xf9, 0xce, 0x4a, 0x8e, 0x96, 0x82, 0xe5, 0xe5, 0xda, 0x3c,
+	0xde, 0xc0, 0x0b, 0xf5, 0xbf, 0x94, 0x7e, 0x99, 0x16, 0x4b, 0xd3, 0xb7, 0xeb, 0x0e, 0xd7, 0x16,
+	0x3a, 0xf3, 0xe4, 0x7e, 0x81, 0xc2, 0x32, 0x85, 0xee, 0xda, 0x70, 0x23, 0xdf, 0x28, 0xb5, 0xed,
+	0x8b, 0x82, 0xf9, 0xf8, 0x3e, 0x76, 0x39, 0xd9, 0x7c, 0xdc, 0x15, 0xc9, 0xae, 0x8d, 0x49, 0xf3,
+	0x78, 0x03, 0x2f, 0x27, 0xbb, 0x31, 0xb3, 0x8a, 0x64, 0xef, 0x9b, 0x99, 0xe6, 0xc9, 0xfd, 0x02,
+	0x85, 0xe5, 0x3f, 0x41, 0xab, 0x18, 0x4c, 0x24, 0x8f, 0x60, 0x7d, 0x04, 0x9a, 0xc6, 0x26, 0xa3,
+	0xb0, 0xf0, 0x23, 0x34, 0xb2, 0xa1, 0x45, 0xf2, 0x8d, 0x7c, 0x65, 0xa8, 0x99, 0x87, 0x6b, 0x68,
+	0xa1, 0xe8, 0x42, 0x67, 0xf5, 0x3d, 0x27, 0x8f, 0x96, 0xef, 0xd3, 0xe6, 0x74, 0x33, 0xbf, 0xb9,
+	0x87, 0x5b, 0xfe, 0x70, 0x6b, 0xcf, 0x6f, 0xf1, 0xe1, 0xb6, 0x0f, 0x01, 0xf3, 0xf1, 0x7d, 0xec,
+	0xdc, 0xe6, 0xf9, 0x4b, 0x68, 0xf5, 0xf3, 0x9f, 0xe0, 0xe4, 0x0a, 0x76, 0x57, 0xca, 0xc2, 0x2c,
+	0x3d, 0x87, 0xeb, 0x35, 0xf1, 0xf5, 0x56, 0x5e, 0x6e, 0xf7, 0x6d, 0x43, 0xfe, 0xcd, 0xf2, 0xec,
+	0xbf, 0x03, 0x00, 0x73, 0xe8, 0x24, 0x2e, 0x75, 0x11, 0x00, 0x00,
 }
@@ -29,6 +29,13 @@ func newChunkRenderer(chunk *pb.Chunk) *chunkRenderer {
 	return &cr
 }
 
+// release frees the GL buffers of a renderer whose chunk is gone
+func (cr *chunkRenderer) release() {
+	buffers := []uint32{cr.pointsVBO, cr.normalsVBO, cr.tcoordsVBO}
+	gl.DeleteBuffers(int32(len(buffers)), &buffers[0])
+	gl.DeleteVertexArrays(1, &cr.drawableVAO)
+}
+
 func generateFace(cellIndex pb.CellIndex, planet *common.Planet, points []float32, tcoords []float32, lonWidth, latWidth int, material int) (pts []float32, nms []float32, tcs []float32) {
 	pts = make([]float32, len(points))
 	for i := 0; i < len(points); i += 3 {
@@ -37,8 +44,7 @@ func generateFace(cellIndex pb.CellIndex, planet *common.Planet, points []float3
 			Lat: float64(cellIndex.Lat) + float64(latWidth-1)/2 + float64(points[i+1])*float64(latWidth),
 			Alt: float64(cellIndex.Alt) + float64(points[i+2]),
 		}
-		r, theta, phi := planet.CellLocToSpherical(l)
-		cart := mgl32.SphericalToCartesian(r, theta, phi)
+		cart := planet.CellVertexToCartesian(cellIndex, l)
 		pts[i] = cart[0]
 		pts[i+1] = cart[1]
 		pts[i+2] = cart[2]
@@ -61,22 +67,30 @@ func generateFace(cellIndex pb.CellIndex, planet *common.Planet, points []float3
 
 	tcs = make([]float32, len(tcoords))
 	for i := 0; i < len(tcoords); i += 2 {
-		tcs[i+0] = (tcoords[i+0] + float32(material%4)) / 4
-		tcs[i+1] = (tcoords[i+1] + float32(material/4)) / 4
+		tcs[i+0] = (tcoords[i+0] + float32(material%textureColumns)) / textureColumns
+		tcs[i+1] = (tcoords[i+1] + float32(material/textureColumns)) / textureColumns
 	}
 
 	return
 }
 
 func (cr *chunkRenderer) updateGeometry(planet *common.Planet, lonIndex, latIndex, altIndex int64) {
+	// A chunk of nothing but air has no faces
+	if cr.chunk.Uniform && cr.chunk.Material == pb.Material_AIR {
+		cr.numTriangles = 0
+		cr.geometryUpdated = true
+		return
+	}
+
 	points := []float32{}
 	normals := []float32{}
 	tcoords := []float32{}
-	const cs = common.ChunkSize
+	cs := planet.ChunkSize
 
 	lonCells, latCells := planet.LonLatCellsInChunkIndex(pb.ChunkIndex{Lon: lonIndex, Lat: latIndex, Alt: altIndex})
-	lonWidth := cs / lonCells
-	latWidth := cs / latCells
+	lonWidth := int(cs) / lonCells
+	latWidth := int(cs) / latCells
+	altCells := int(cs)
 
 	chunkPosAlt := planet.Chunks[common.ChunkKey{Lon: lonIndex, Lat: latIndex, Alt: altIndex + 1}]
 	maxAltChunk := altIndex >= planet.Spec.AltCells/cs-1
@@ -97,6 +111,9 @@ func (cr *chunkRenderer) updateGeometry(planet *common.Planet, lonIndex, latInde
 	chunkNegLon := planet.Chunks[common.ChunkKey{Lon: lonNeg, Lat: latIndex, Alt: altIndex}]
 
 	hasAirAlt := func(c *pb.Chunk, lon, lat, alt int) bool {
+		if c.Uniform {
+			return c.Material == pb.Material_AIR
+		}
 		if len(c.Cell) <= lonCells || len(c.Cell[0].Cell) <= latCells {
 			lonFactor := lonCells / len(c.Cell)
 			latFactor := latCells / len(c.Cell[0].Cell)
@@ -115,6 +132,9 @@ func (cr *chunkRenderer) updateGeometry(planet *common.Planet, lonIndex, latInde
 	}
 
 	hasAirLat := func(c *pb.Chunk, lon, lat, alt int) bool {
+		if c.Uniform {
+			return c.Material == pb.Material_AIR
+		}
 		if len(c.Cell[0].Cell) != latCells {
 			panic(errors.New("Chunks with same lon and alt should have the same lat cells"))
 		}
@@ -132,54 +152,118 @@ func (cr *chunkRenderer) updateGeometry(planet *common.Planet, lonIndex, latInde
 	}
 
 	hasAirLon := func(c *pb.Chunk, lon, lat, alt int) bool {
+		if c.Uniform {
+			return c.Material == pb.Material_AIR
+		}
 		if len(c.Cell) != lonCells || len(c.Cell[0].Cell) != latCells {
 			panic(errors.New("Chunks with same lat and alt should have the same cell dimensions"))
 		}
 		return c.Cell[lon].Cell[lat].Cell[alt].Material == pb.Material_AIR
 	}
 
+	// Where the cells across a side of the chunk are not in the chunk next to it by index, such as along the edge
+	// of a cube-sphere face where the chunk across may be turned, they are looked up one at a time
+	corner := pb.CellIndex{Lon: cs * lonIndex, Lat: cs * latIndex}
+	acrossSide := func(ind pb.CellIndex, dLon, dLat, lon, lat int64) bool {
+		neighbor, ok := planet.NeighborCellIndex(ind, dLon, dLat, 0)
+		if !ok {
+			return true
+		}
+		nInd := planet.CellIndexToChunkIndex(neighbor)
+		return nInd.Lon != lon || nInd.Lat != lat
+	}
+	acrossNegLon := acrossSide(corner, -1, 0, lonNeg, latIndex)
+	acrossPosLon := acrossSide(pb.CellIndex{Lon: corner.Lon + cs - 1, Lat: corner.Lat}, 1, 0, lonPos, latIndex)
+	acrossNegLat := acrossSide(corner, 0, -1, lonIndex, latIndex-1)
+	acrossPosLat := acrossSide(pb.CellIndex{Lon: corner.Lon, Lat: corner.Lat + cs - 1}, 0, 1, lonIndex, latIndex+1)
+
+	hasAirAcross := func(ind pb.CellIndex, dLon, dLat int64) bool {
+		neighbor, ok := planet.NeighborCellIndex(ind, dLon, dLat, 0)
+		if !ok {
+			// Past the edge of an open world there is nothing to hide its sides
+			return !planet.Topology.Closed()
+		}
+		nInd := planet.CellIndexToChunkIndex(neighbor)
+		c := planet.Chunks[common.ChunkKey{Lon: nInd.Lon, Lat: nInd.Lat, Alt: nInd.Alt}]
+		if c != nil && c.Uniform {
+			return c.Material == pb.Material_AIR
+		}
+		if c == nil || len(c.Cell) == 0 {
+			return false
+		}
+		nLonCells, nLatCells := planet.LonLatCellsInChunkIndex(nInd)
+		return c.Cell[neighbor.Lon%cs/(cs/int64(nLonCells))].Cell[neighbor.Lat%cs/(cs/int64(nLatCells))].Cell[neighbor.Alt%cs].Material == pb.Material_AIR
+	}
+
+	airPosLon := func(ind pb.CellIndex, cLat, cAlt int) bool {
+		if acrossPosLon {
+			ind.Lon += int64(lonWidth - 1)
+			return hasAirAcross(ind, 1, 0)
+		}
+		return chunkPosLon != nil && hasAirLon(chunkPosLon, 0, cLat, cAlt)
+	}
+	airNegLon := func(ind pb.CellIndex, cLat, cAlt int) bool {
+		if acrossNegLon {
+			return hasAirAcross(ind, -1, 0)
+		}
+		return chunkNegLon != nil && hasAirLon(chunkNegLon, lonCells-1, cLat, cAlt)
+	}
+	airPosLat := func(ind pb.CellIndex, cLon, cAlt int) bool {
+		if acrossPosLat {
+			ind.Lat += int64(latWidth - 1)
+			return hasAirAcross(ind, 0, 1)
+		}
+		return chunkPosLat != nil && hasAirLat(chunkPosLat, cLon, 0, cAlt)
+	}
+	airNegLat := func(ind pb.CellIndex, cLon, cAlt int) bool {
+		if acrossNegLat {
+			return hasAirAcross(ind, 0, -1)
+		}
+		return chunkNegLat != nil && hasAirLat(chunkNegLat, cLon, latCells-1, cAlt)
+	}
+
 	for cLon := 0; cLon < lonCells; cLon++ {
 		for cLat := 0; cLat < latCells; cLat++ {
-			for cAlt := 0; cAlt < cs; cAlt++ {
+			for cAlt := 0; cAlt < altCells; cAlt++ {
 				cellIndex := pb.CellIndex{
 					Lon: cs*lonIndex + int64(cLon*lonWidth),
 					Lat: cs*latIndex + int64(cLat*latWidth),
 					Alt: cs*altIndex + int64(cAlt),
 				}
-				cell := cr.chunk.Cell[cLon].Cell[cLat].Cell[cAlt]
-				if cell.Material != pb.Material_AIR {
-					if (cAlt+1 >= cs && chunkPosAlt != nil && hasAirAlt(chunkPosAlt, cLon, cLat, 0)) || (cAlt+1 >= cs && maxAltChunk) || (cAlt+1 < cs && cr.chunk.Cell[cLon].Cell[cLat].Cell[cAlt+1].Material == pb.Material_AIR) {
-						pts, nms, tcs := generateFace(cellIndex, planet, cubePosZ, cubeTcoordPosZ, lonWidth, latWidth, int(cell.Material))
+				material := common.ChunkMaterial(cr.chunk, cLon, cLat, cAlt)
+				if material != pb.Material_AIR {
+					if (cAlt+1 >= altCells && chunkPosAlt != nil && hasAirAlt(chunkPosAlt, cLon, cLat, 0)) || (cAlt+1 >= altCells && maxAltChunk) || (cAlt+1 < altCells && common.ChunkMaterial(cr.chunk, cLon, cLat, cAlt+1) == pb.Material_AIR) {
+						pts, nms, tcs := generateFace(cellIndex, planet, cubePosZ, cubeTcoordPosZ, lonWidth, latWidth, int(material))
 						points = append(points, pts...)
 						normals = append(normals, nms...)
 						tcoords = append(tcoords, tcs...)
 					}
-					if (cAlt-1 < 0 && chunkNegAlt != nil && hasAirAlt(chunkNegAlt, cLon, cLat, cs-1)) || (cAlt-1 < 0 && minAltChunk) || (cAlt-1 >= 0 && cr.chunk.Cell[cLon].Cell[cLat].Cell[cAlt-1].Material == pb.Material_AIR) {
-						pts, nms, tcs := generateFace(cellIndex, planet, cubeNegZ, cubeTcoordNegZ, lonWidth, latWidth, int(cell.Material))
+					if (cAlt-1 < 0 && chunkNegAlt != nil && hasAirAlt(chunkNegAlt, cLon, cLat, altCells-1)) || (cAlt-1 < 0 && minAltChunk) || (cAlt-1 >= 0 && common.ChunkMaterial(cr.chunk, cLon, cLat, cAlt-1) == pb.Material_AIR) {
+						pts, nms, tcs := generateFace(cellIndex, planet, cubeNegZ, cubeTcoordNegZ, lonWidth, latWidth, int(material))
 						points = append(points, pts...)
 						normals = append(normals, nms...)
 						tcoords = append(tcoords, tcs...)
 					}
-					if (cLon+1 >= lonCells && chunkPosLon != nil && hasAirLon(chunkPosLon, 0, cLat, cAlt)) || (cLon+1 < lonCells && cr.chunk.Cell[cLon+1].Cell[cLat].Cell[cAlt].Material == pb.Material_AIR) {
-						pts, nms, tcs := generateFace(cellIndex, planet, cubePosX, cubeTcoordPosX, lonWidth, latWidth, int(cell.Material))
+					if (cLon+1 >= lonCells && airPosLon(cellIndex, cLat, cAlt)) || (cLon+1 < lonCells && common.ChunkMaterial(cr.chunk, cLon+1, cLat, cAlt) == pb.Material_AIR) {
+						pts, nms, tcs := generateFace(cellIndex, planet, cubePosX, cubeTcoordPosX, lonWidth, latWidth, int(material))
 						points = append(points, pts...)
 						normals = append(normals, nms...)
 						tcoords = append(tcoords, tcs...)
 					}
-					if (cLon-1 < 0 && chunkNegLon != nil && hasAirLon(chunkNegLon, lonCells-1, cLat, cAlt)) || (cLon-1 >= 0 && cr.chunk.Cell[cLon-1].Cell[cLat].Cell[cAlt].Material == pb.Material_AIR) {
-						pts, nms, tcs := generateFace(cellIndex, planet, cubeNegX, cubeTcoordNegX, lonWidth, latWidth, int(cell.Material))
+					if (cLon-1 < 0 && airNegLon(cellIndex, cLat, cAlt)) || (cLon-1 >= 0 && common.ChunkMaterial(cr.chunk, cLon-1, cLat, cAlt) == pb.Material_AIR) {
+						pts, nms, tcs := generateFace(cellIndex, planet, cubeNegX, cubeTcoordNegX, lonWidth, latWidth, int(material))
 						points = append(points, pts...)
 						normals = append(normals, nms...)
 						tcoords = append(tcoords, tcs...)
 					}
-					if (cLat+1 >= latCells && chunkPosLat != nil && hasAirLat(chunkPosLat, cLon, 0, cAlt)) || (cLat+1 < latCells && cr.chunk.Cell[cLon].Cell[cLat+1].Cell[cAlt].Material == pb.Material_AIR) {
-						pts, nms, tcs := generateFace(cellIndex, planet, cubePosY, cubeTcoordPosY, lonWidth, latWidth, int(cell.Material))
+					if (cLat+1 >= latCells && airPosLat(cellIndex, cLon, cAlt)) || (cLat+1 < latCells && common.ChunkMaterial(cr.chunk, cLon, cLat+1, cAlt) == pb.Material_AIR) {
+						pts, nms, tcs := generateFace(cellIndex, planet, cubePosY, cubeTcoordPosY, lonWidth, latWidth, int(material))
 						points = append(points, pts...)
 						normals = append(normals, nms...)
 						tcoords = append(tcoords, tcs...)
 					}
-					if (cLat-1 < 0 && chunkNegLat != nil && hasAirLat(chunkNegLat, cLon, latCells-1, cAlt)) || (cLat-1 >= 0 && cr.chunk.Cell[cLon].Cell[cLat-1].Cell[cAlt].Material == pb.Material_AIR) {
-						pts, nms, tcs := generateFace(cellIndex, planet, cubeNegY, cubeTcoordNegY, lonWidth, latWidth, int(cell.Material))
+					if (cLat-1 < 0 && airNegLat(cellIndex, cLon, cAlt)) || (cLat-1 >= 0 && common.ChunkMaterial(cr.chunk, cLon, cLat-1, cAlt) == pb.Material_AIR) {
+						pts, nms, tcs := generateFace(cellIndex, planet, cubeNegY, cubeTcoordNegY, lonWidth, latWidth, int(material))
 						points = append(points, pts...)
 						normals = append(normals, nms...)
 						tcoords = append(tcoords, tcs...)
@@ -54,8 +54,8 @@ func NewFocusCell() *FocusCell {
 func (focusRen *FocusCell) Draw(player *common.Player, planet *common.Planet, w *glfw.Window) {
 	gl.UseProgram(focusRen.program)
 	lonCells, latCells := planet.LonLatCellsInChunkIndex(planet.CellIndexToChunkIndex(player.FocusCellIndex))
-	lonWidth := common.ChunkSize / lonCells
-	latWidth := common.ChunkSize / latCells
+	lonWidth := int(planet.ChunkSize) / lonCells
+	latWidth := int(planet.ChunkSize) / latCells
 
 	pts := make([]float32, len(box))
 	for i := 0; i < len(box); i += 3 {
@@ -64,7 +64,7 @@ func (focusRen *FocusCell) Draw(player *common.Player, planet *common.Planet, w
 			Lat: float64(int(player.FocusCellIndex.Lat)/latWidth*latWidth) + float64(latWidth-1)/2 + float64(latWidth)*float64(box[i+1]*1.01),
 			Alt: float64(player.FocusCellIndex.Alt) + float64(box[i+2]*1.01),
 		}
-		pt := planet.CellLocToCartesian(ind)
+		pt := planet.CellVertexToCartesian(player.FocusCellIndex, ind)
 		pts[i+0] = pt.X()
 		pts[i+1] = pt.Y()
 		pts[i+2] = pt.Z()
@@ -73,7 +73,8 @@ func (focusRen *FocusCell) Draw(player *common.Player, planet *common.Planet, w
 	fillVBO(focusRen.pointsVBO, pts)
 
 	lookDir := player.LookDir()
-	view := mgl32.LookAtV(player.Location(), player.Location().Add(lookDir), player.Location().Normalize())
+	loc := player.RenderLocation()
+	view := mgl32.LookAtV(loc, loc.Add(lookDir), planet.Up(loc))
 	width, height := FramebufferSize(w)
 	perspective := mgl32.Perspective(float32(60*math.Pi/180), float32(width)/float32(height), 0.01, 1000)
 	proj := perspective.Mul4(view)
@@ -142,3 +142,11 @@ func uniformLocation(program uint32, name string) int32 {
 	free()
 	return uniform
 }
+
+// glBool converts a bool to the int that sets a bool uniform
+func glBool(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -86,8 +86,8 @@ func (h *Hotbar) computeGeometry(player *common.Player, width, height int) {
 	points := []float32{}
 	sz := float32(0.03)
 	for m, mat := range player.Hotbar {
-		mx := float32(mat.Material % 4)
-		my := float32(mat.Material / 4)
+		mx := float32(mat.Material % textureColumns)
+		my := float32(mat.Material / textureColumns)
 		px := 1.25 * 2 * sz * (float32(m+1) - float32(len(player.Hotbar)+1)/2)
 		py := 1 - 0.1*aspect
 		scale := sz
@@ -99,8 +99,8 @@ func (h *Hotbar) computeGeometry(player *common.Player, width, height int) {
 			pts = append(pts, []float32{
 				px + sq[i+0]*scale,
 				py + sq[i+1]*scale*aspect,
-				(mx + (sq[i+0]+1)/2) / 4,
-				(my + (sq[i+1]+1)/2) / 4,
+				(mx + (sq[i+0]+1)/2) / textureColumns,
+				(my + (sq[i+1]+1)/2) / textureColumns,
 			}...)
 		}
 		points = append(points, pts...)
@@ -108,8 +108,8 @@ func (h *Hotbar) computeGeometry(player *common.Player, width, height int) {
 	if player.Mode == "Inventory" {
 		if player.GameMode == common.Creative {
 			for m := 1; m < len(common.Materials); m++ {
-				mx := float32(m % 4)
-				my := float32(m / 4)
+				mx := float32(m % textureColumns)
+				my := float32(m / textureColumns)
 				px := 1.25 * 2 * sz * (float32(m) - float32(len(common.Materials))/2)
 				py := 1 - 0.25*aspect
 				scale := sz
@@ -118,8 +118,8 @@ func (h *Hotbar) computeGeometry(player *common.Player, width, height int) {
 					pts = append(pts, []float32{
 						px + sq[i+0]*scale,
 						py + sq[i+1]*scale*aspect,
-						(mx + (sq[i+0]+1)/2) / 4,
-						(my + (sq[i+1]+1)/2) / 4,
+						(mx + (sq[i+0]+1)/2) / textureColumns,
+						(my + (sq[i+1]+1)/2) / textureColumns,
 					}...)
 				}
 				points = append(points, pts...)
@@ -130,8 +130,8 @@ func (h *Hotbar) computeGeometry(player *common.Player, width, height int) {
 					slotInd := row*12 + col
 					slot := player.Inventory[slotInd]
 					m := slot.Material
-					mx := float32(m % 4)
-					my := float32(m / 4)
+					mx := float32(m % textureColumns)
+					my := float32(m / textureColumns)
 					px := 1.25 * 2 * sz * (float32(col) - float32(12)/2)
 					py := 1 - 0.25*aspect
 					scale := sz
@@ -140,8 +140,8 @@ func (h *Hotbar) computeGeometry(player *common.Player, width, height int) {
 						pts = append(pts, []float32{
 							px + sq[i+0]*scale,
 							py + sq[i+1]*scale*aspect,
-							(mx + (sq[i+0]+1)/2) / 4,
-							(my + (sq[i+1]+1)/2) / 4,
+							(mx + (sq[i+0]+1)/2) / textureColumns,
+							(my + (sq[i+1]+1)/2) / textureColumns,
 						}...)
 					}
 					points = append(points, pts...)
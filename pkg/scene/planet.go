@@ -20,11 +20,15 @@ type Planet struct {
 	projectionUniform int32
 	planetLocUniform  int32
 	planetRotUniform  int32
+	sunLocUniform     int32
+	selfLitUniform    int32
 
 	chunkProgram           uint32
 	chunkProjectionUniform int32
 	chunkPlanetLocUniform  int32
 	chunkPlanetRotUniform  int32
+	chunkSunLocUniform     int32
+	chunkSelfLitUniform    int32
 	chunkTextureUniform    int32
 
 	drawableVAO     uint32
@@ -43,6 +47,8 @@ func NewPlanet(planet *common.Planet) *Planet {
 		uniform mat4 proj;
 		uniform mat3 planetrot;
 		uniform vec3 planetloc;
+		uniform vec3 sunloc;
+		uniform bool selflit;
 		out vec4 color;
 		out vec3 light;
 		void main() {
@@ -50,7 +56,12 @@ func NewPlanet(planet *common.Planet) *Planet {
 			gl_Position = proj * vec4(vp, 1.0);
 
 			highp vec3 rotated = planetrot * vp;
-			highp vec3 sundir = normalize(planetloc + rotated);
+
+			// The sun lights itself, and lights everything else from where it is
+			highp vec3 sundir = normalize(rotated);
+			if (!selflit) {
+				sundir = normalize(sunloc - (planetloc + rotated));
+			}
 
 			// Apply lighting effect
 			highp vec3 ambientLight = vec3(0, 0, 0);
@@ -83,6 +94,8 @@ func NewPlanet(planet *common.Planet) *Planet {
 		uniform mat4 proj;
 		uniform mat3 planetrot;
 		uniform vec3 planetloc;
+		uniform vec3 sunloc;
+		uniform bool selflit;
 		out vec3 light;
 		out vec2 texcoord;
 		void main() {
@@ -90,7 +103,12 @@ func NewPlanet(planet *common.Planet) *Planet {
 			gl_Position = proj * vec4(vp, 1.0);
 
 			highp vec3 rotated = planetrot * vp;
-			highp vec3 sundir = normalize(planetloc + rotated);
+
+			// The sun lights itself, and lights everything else from where it is
+			highp vec3 sundir = normalize(rotated);
+			if (!selflit) {
+				sundir = normalize(sunloc - (planetloc + rotated));
+			}
 
 			// Apply lighting effect
 			highp vec3 ambientLight = vec3(0, 0, 0);
@@ -128,6 +146,8 @@ func NewPlanet(planet *common.Planet) *Planet {
 	pr.chunkProjectionUniform = uniformLocation(pr.chunkProgram, "proj")
 	pr.chunkPlanetLocUniform = uniformLocation(pr.chunkProgram, "planetloc")
 	pr.chunkPlanetRotUniform = uniformLocation(pr.chunkProgram, "planetrot")
+	pr.chunkSunLocUniform = uniformLocation(pr.chunkProgram, "sunloc")
+	pr.chunkSelfLitUniform = uniformLocation(pr.chunkProgram, "selflit")
 	pr.chunkTextureUniform = uniformLocation(pr.chunkProgram, "texBase")
 
 	pr.program = createProgramNoLink(vertexShader, fragmentShader)
@@ -138,6 +158,8 @@ func NewPlanet(planet *common.Planet) *Planet {
 	pr.projectionUniform = uniformLocation(pr.program, "proj")
 	pr.planetLocUniform = uniformLocation(pr.program, "planetloc")
 	pr.planetRotUniform = uniformLocation(pr.program, "planetrot")
+	pr.sunLocUniform = uniformLocation(pr.program, "sunloc")
+	pr.selfLitUniform = uniformLocation(pr.program, "selflit")
 
 	rgba := LoadTextures()
 
@@ -185,98 +207,58 @@ func (planetRen *Planet) SetCellMaterial(ind pb.CellIndex, material pb.Material,
 	// Mark the chunk's geometry to be recalculated
 	chunkRen.geometryUpdated = false
 
-	lonCells := len(chunkRen.chunk.Cell)
-	latCells := len(chunkRen.chunk.Cell[0].Cell)
-	lonFactor := common.ChunkSize / lonCells
-	latFactor := common.ChunkSize / latCells
-
-	// If the cell is along a chunk edge, also mark the adjacent chunk dirty
-	if int(ind.Lon%common.ChunkSize)/lonFactor == 0 {
-		lonChunks := planetRen.Planet.LonCells / common.ChunkSize
-		lonNeg := chunkInd.Lon - 1
-		if lonNeg < 0 {
-			lonNeg = lonChunks - 1
-		}
-		cr := planetRen.chunkRenderers[common.ChunkKey{Lon: lonNeg, Lat: chunkInd.Lat, Alt: chunkInd.Alt}]
-		if cr != nil {
-			cr.geometryUpdated = false
-		}
-	}
-	if int(ind.Lon%common.ChunkSize)/lonFactor == lonCells-1 {
-		lonChunks := planetRen.Planet.LonCells / common.ChunkSize
-		lonPos := (chunkInd.Lon + 1) % lonChunks
-		cr := planetRen.chunkRenderers[common.ChunkKey{Lon: lonPos, Lat: chunkInd.Lat, Alt: chunkInd.Alt}]
-		if cr != nil {
-			cr.geometryUpdated = false
-		}
-	}
-	if int(ind.Lat%common.ChunkSize)/latFactor == 0 {
-		cr := planetRen.chunkRenderers[common.ChunkKey{Lon: chunkInd.Lon, Lat: chunkInd.Lat - 1, Alt: chunkInd.Alt}]
-		if cr != nil {
-			cr.geometryUpdated = false
-		}
-	}
-	if int(ind.Lat%common.ChunkSize)/latFactor == latCells-1 {
-		cr := planetRen.chunkRenderers[common.ChunkKey{Lon: chunkInd.Lon, Lat: chunkInd.Lat + 1, Alt: chunkInd.Alt}]
-		if cr != nil {
-			cr.geometryUpdated = false
-		}
-	}
-	if ind.Alt%common.ChunkSize == 0 {
-		cr := planetRen.chunkRenderers[common.ChunkKey{Lon: chunkInd.Lon, Lat: chunkInd.Lat, Alt: chunkInd.Alt - 1}]
-		if cr != nil {
-			cr.geometryUpdated = false
-		}
-	}
-	if ind.Alt%common.ChunkSize == common.ChunkSize-1 {
-		cr := planetRen.chunkRenderers[common.ChunkKey{Lon: chunkInd.Lon, Lat: chunkInd.Lat, Alt: chunkInd.Alt + 1}]
-		if cr != nil {
+	// If the cell is along a chunk edge, also mark the adjacent chunks dirty
+	for _, key := range planetRen.Planet.AdjacentChunkKeys(ind) {
+		if cr := planetRen.chunkRenderers[key]; cr != nil {
 			cr.geometryUpdated = false
 		}
 	}
 }
 
 func (planetRen *Planet) location(time float64, planetMap map[int64]*Planet) mgl32.Vec3 {
-	planet := planetRen.Planet
-	if planet.Spec.Id == planet.Spec.OrbitPlanet {
-		return mgl32.Vec3{}
+	return common.SystemLocation(planetRen.Planet.Spec.Id, time, func(id int64) *pb.PlanetSpec {
+		p := planetMap[id]
+		if p == nil {
+			return nil
+		}
+		return &p.Planet.Spec
+	})
+}
+
+// sunLocation returns where the light falling on the planet comes from at a time in seconds, and whether the planet
+// lights itself instead. The light comes from the system's star, as in the sun elevation the server reports.
+// Without a star, the planet at the origin lights itself and the rest.
+func (planetRen *Planet) sunLocation(time float64, planetMap map[int64]*Planet) (mgl32.Vec3, bool) {
+	specs := []*pb.PlanetSpec{}
+	for _, p := range planetMap {
+		specs = append(specs, &p.Planet.Spec)
 	}
-	orbitLoc := planetMap[planet.Spec.OrbitPlanet].location(time, planetMap)
-	_, timeOfOrbit := math.Modf(time / planet.Spec.OrbitSeconds)
-	orbitAng := 2 * math.Pi * timeOfOrbit
-	loc := orbitLoc.Add(
-		mgl32.Vec3{
-			float32(math.Cos(orbitAng)),
-			float32(math.Sin(orbitAng)),
-			0,
-		}.Mul(float32(planet.Spec.OrbitDistance)),
-	)
-	return loc
+	id, ok := common.StarID(specs)
+	if !ok {
+		return mgl32.Vec3{}, planetRen.location(time, planetMap).Len() == 0
+	}
+	if id == planetRen.Planet.Spec.Id {
+		return mgl32.Vec3{}, true
+	}
+	return planetMap[id].location(time, planetMap), false
 }
 
 // Draw draws the planet's visible chunks
 func (planetRen *Planet) Draw(player *common.Player, planetMap map[int64]*Planet, w *glfw.Window, time float64) {
-	loc := player.Location()
-	planetRotation := time / planetRen.Planet.Spec.RotationSeconds
-	planetRotation *= 2 * math.Pi
-	orbitPosition := time / planetRen.Planet.Spec.OrbitSeconds
-	orbitPosition *= 2 * math.Pi
+	loc := player.RenderLocation()
 	lookDir := player.LookDir()
-	view := mgl32.LookAtV(loc, loc.Add(lookDir), loc.Normalize())
+	view := mgl32.LookAtV(loc, loc.Add(lookDir), player.Planet.Up(loc))
 	planetLoc := planetRen.location(time, planetMap)
-	planetRotate := mgl32.Rotate3DZ(float32(planetRotation))
-	planetRotateNeg := mgl32.Rotate3DZ(-float32(planetRotation))
+	planetRotate := common.PlanetRotation(&planetRen.Planet.Spec, time)
+	sunLoc, selfLit := planetRen.sunLocation(time, planetMap)
 	farPlane := float32(1000)
 	if player.Planet.Spec.Id != planetRen.Planet.Spec.Id {
+		// Take the planet's coordinates to the universe, then into the coordinates of the player's planet
 		playerPlanetLoc := planetMap[player.Planet.Spec.Id].location(time, planetMap)
-		planetLoc = planetRen.location(time, planetMap)
-		relativeLoc := playerPlanetLoc.Sub(planetLoc)
-		_, playerPlanetRotation := math.Modf(time / player.Planet.Spec.RotationSeconds)
-		playerPlanetRotation *= 2 * math.Pi
-		playerPlanetRotate := mgl32.HomogRotate3DZ(float32(playerPlanetRotation))
+		relativeLoc := planetLoc.Sub(playerPlanetLoc)
+		playerPlanetRotateInv := common.PlanetRotation(&player.Planet.Spec, time).Transpose().Mat4()
 		translate := mgl32.Translate3D(relativeLoc[0], relativeLoc[1], relativeLoc[2])
-		planetRotate4 := mgl32.HomogRotate3DZ(float32(planetRotation))
-		view = view.Mul4(playerPlanetRotate).Mul4(translate).Mul4(planetRotate4)
+		view = view.Mul4(playerPlanetRotateInv).Mul4(translate).Mul4(planetRotate.Mat4())
 		farPlane = 10000
 	}
 	width, height := FramebufferSize(w)
@@ -288,15 +270,21 @@ func (planetRen *Planet) Draw(player *common.Player, planetMap map[int64]*Planet
 		gl.UniformMatrix4fv(planetRen.projectionUniform, 1, false, &proj[0])
 		gl.UniformMatrix3fv(planetRen.planetRotUniform, 1, false, &planetRotate[0])
 		gl.Uniform3f(planetRen.planetLocUniform, planetLoc[0], planetLoc[1], planetLoc[2])
+		gl.Uniform3f(planetRen.sunLocUniform, sunLoc[0], sunLoc[1], sunLoc[2])
+		gl.Uniform1i(planetRen.selfLitUniform, glBool(selfLit))
 		planetRen.drawGeometry()
+		// Only the player's planet draws its chunks
+		planetRen.releaseChunkRenderers(true)
 		return
 	}
 
 	gl.UseProgram(planetRen.chunkProgram)
 	gl.UniformMatrix4fv(planetRen.chunkProjectionUniform, 1, false, &proj[0])
-	gl.UniformMatrix3fv(planetRen.chunkPlanetRotUniform, 1, false, &planetRotateNeg[0])
+	gl.UniformMatrix3fv(planetRen.chunkPlanetRotUniform, 1, false, &planetRotate[0])
 	gl.Uniform1i(planetRen.chunkTextureUniform, planetRen.textureUnit)
 	gl.Uniform3f(planetRen.chunkPlanetLocUniform, planetLoc[0], planetLoc[1], planetLoc[2])
+	gl.Uniform3f(planetRen.chunkSunLocUniform, sunLoc[0], sunLoc[1], sunLoc[2])
+	gl.Uniform1i(planetRen.chunkSelfLitUniform, glBool(selfLit))
 	planetRen.Planet.ChunksMutex.Lock()
 	for key, chunk := range planetRen.Planet.Chunks {
 		if chunk.WaitingForData {
@@ -313,6 +301,19 @@ func (planetRen *Planet) Draw(player *common.Player, planetMap map[int64]*Planet
 		cr.draw()
 	}
 	planetRen.Planet.ChunksMutex.Unlock()
+	planetRen.releaseChunkRenderers(false)
+}
+
+// releaseChunkRenderers frees the renderers of chunks that were evicted, or evicted and loaded again, or else all of them
+func (planetRen *Planet) releaseChunkRenderers(all bool) {
+	planetRen.Planet.ChunksMutex.Lock()
+	defer planetRen.Planet.ChunksMutex.Unlock()
+	for key, cr := range planetRen.chunkRenderers {
+		if all || planetRen.Planet.Chunks[key] != cr.chunk {
+			cr.release()
+			delete(planetRen.chunkRenderers, key)
+		}
+	}
 }
 
 func (planetRen *Planet) updateGeometry() {
@@ -327,14 +328,12 @@ func (planetRen *Planet) updateGeometry() {
 	latCells := len(geom.Altitude[0].Altitude)
 
 	appendAttributesForIndex := func(cLat, cLon int) {
-		cellIndex := pb.CellIndex{
-			Lon: p.LonCells * int64(cLon) / int64(lonCells),
-			Lat: p.LatCells * int64(cLat) / int64(latCells-1),
-			Alt: geom.Altitude[cLon].Altitude[cLat],
-		}
-		pt := planetRen.Planet.CellIndexToCartesian(cellIndex)
-		nm := pt.Normalize()
+		pt := p.GeometrySampleToCartesian(cLon, cLat, lonCells, latCells, geom.Altitude[cLon].Altitude[cLat])
+		nm := p.Up(pt)
 		c := common.MaterialColors[geom.Material[cLon].Material[cLat]]
+		if cLon < len(geom.Biome) && cLat < len(geom.Biome[cLon].Biome) {
+			c = common.DistantColor(geom.Biome[cLon].Biome[cLat], geom.Material[cLon].Material[cLat])
+		}
 		points = append(points, pt[0], pt[1], pt[2])
 		normals = append(normals, nm[0], nm[1], nm[2])
 		colors = append(colors, c[0], c[1], c[2], 1.0)
@@ -94,12 +94,13 @@ func (peopleRen *Players) Draw(player *common.Player, w *glfw.Window) {
 		fillVBO(peopleRen.normalsVBO, nms)
 
 		lookDir := player.LookDir()
-		view := mgl32.LookAtV(player.Location(), player.Location().Add(lookDir), player.Location().Normalize())
+		loc := player.RenderLocation()
+		view := mgl32.LookAtV(loc, loc.Add(lookDir), player.Planet.Up(loc))
 		width, height := FramebufferSize(w)
 		perspective := mgl32.Perspective(45, float32(width)/float32(height), 0.01, 1000)
 		proj := perspective.Mul4(view)
 		proj = proj.Mul4(mgl32.Translate3D(p.Position[0], p.Position[1], p.Position[2]))
-		right := p.LookDir.Cross(p.Position.Normalize()).Normalize()
+		right := p.LookDir.Cross(player.Planet.Up(p.Position)).Normalize()
 		up := right.Cross(p.LookDir).Normalize()
 		proj = proj.Mul4(mgl32.Mat4FromCols(p.LookDir.Vec4(0), up.Vec4(0), right.Vec4(0), mgl32.Vec4{0, 0, 0, 1}))
 		gl.UniformMatrix4fv(peopleRen.projectionUniform, 1, false, &proj[0])
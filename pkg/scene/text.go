@@ -45,7 +45,8 @@ func (text *Text) Draw(player *common.Player, screen *gui.Screen, u *Universe) {
 		})
 		o = 1
 	}
-	tex1.Text = fmt.Sprintf("LAT %v, LON %v, ALT %v", int(theta/math.Pi*180-90+0.5), int(phi/math.Pi*180+0.5), int(r+0.5))
+	spec := player.Planet.Spec
+	tex1.Text = fmt.Sprintf("%v (GRAVITY %.1f, ATMOSPHERE %.1f) LAT %v, LON %v, ALT %v", spec.Name, player.Planet.SurfaceGravity(), spec.AtmosphereDensity, int(theta/math.Pi*180-90+0.5), int(phi/math.Pi*180+0.5), int(r+0.5))
 	if player.Mode == "Text" {
 		texte.Y = -0.85
 		texte.Focus = true
@@ -35,17 +35,18 @@ func (u *Universe) AddPlanet(planet *Planet) {
 // Draw draws the universe's planets
 func (u *Universe) Draw(w *glfw.Window, time float64) {
 	player := u.Player
-	loc := player.Location()
+	loc := player.RenderLocation()
 	planetRen := u.PlanetMap[player.Planet.Spec.Id]
-	planetRotation := time / planetRen.Planet.Spec.RotationSeconds
-	planetRotation *= 2 * math.Pi
-	orbitPosition := time / planetRen.Planet.Spec.OrbitSeconds
-	orbitPosition *= 2 * math.Pi
 	planetLoc := planetRen.location(time, u.PlanetMap)
-	planetRotateNeg := mgl32.Rotate3DZ(-float32(planetRotation))
+	planetRotate := common.PlanetRotation(&planetRen.Planet.Spec, time)
 
-	rotated := planetRotateNeg.Mul3x1(loc)
-	sunDir := planetLoc.Add(rotated).Normalize()
+	sunLoc, selfLit := planetRen.sunLocation(time, u.PlanetMap)
+
+	rotated := planetRotate.Mul3x1(loc)
+	sunDir := rotated.Normalize()
+	if !selfLit {
+		sunDir = sunLoc.Sub(planetLoc.Add(rotated)).Normalize()
+	}
 
 	vpnDotSun := float64(rotated.Normalize().Dot(sunDir))
 	light1Color := mgl32.Vec3{0.5, 0.7, 1.0}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jeffbaumes/govox/pkg/common"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// A world archive is a zip file holding a manifest and a file for each planet spec, stored chunk and entity:
+//
+//	manifest.json                        worldManifest as JSON
+//	planets/<id>.pb                      PlanetSpec protobuf
+//	chunks/<planet>/<lon>_<lat>_<alt>.pb Chunk protobuf
+//	entities/<escaped name>.bin          entity data
+const (
+	archiveFormat   = "govox-world"
+	archiveVersion  = 1
+	archiveManifest = "manifest.json"
+)
+
+// worldManifest describes the world in an archive, with the SHA-256 checksum of every other file in it
+type worldManifest struct {
+	Format   string            `json:"format"`
+	Version  int               `json:"version"`
+	Seed     int64             `json:"seed"`
+	Metadata map[string]string `json:"metadata"`
+	Files    map[string]string `json:"files"`
+}
+
+// archiveMetadataSkipped lists the metadata an archive leaves out, since it is about how the world is stored or in the manifest already
+var archiveMetadataSkipped = map[string]bool{"schemaVersion": true, "seed": true}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ExportWorld writes a world to an archive that does not depend on how worlds are stored.
+// It reads a snapshot of the world, so the world may be served while it is exported.
+func ExportWorld(name, archivePath string) {
+	dbName := "worlds/" + name + ".db"
+	if _, err := os.Stat(dbName); err != nil {
+		log.Fatalf("failed to find world: %v", err)
+	}
+	world, err := sql.Open("sqlite3", dbName)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	snapshotName := filepath.Join(os.TempDir(), fmt.Sprintf("govox-export-%d.db", time.Now().UnixNano()))
+	if _, err := world.Exec("VACUUM INTO ?", snapshotName); err != nil {
+		log.Fatalf("failed to snapshot world: %v", err)
+	}
+	world.Close()
+	defer os.Remove(snapshotName)
+	db, seed := openWorld(snapshotName, 0)
+	defer db.Close()
+
+	manifest := worldManifest{
+		Format:   archiveFormat,
+		Version:  archiveVersion,
+		Seed:     seed,
+		Metadata: map[string]string{},
+		Files:    map[string]string{},
+	}
+	rows, err := db.Query("SELECT key, value FROM metadata")
+	if err != nil {
+		log.Fatalf("failed to read metadata: %v", err)
+	}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			log.Fatalf("failed to read metadata: %v", err)
+		}
+		if !archiveMetadataSkipped[key] {
+			manifest.Metadata[key] = value
+		}
+	}
+	rows.Close()
+
+	file, err := os.Create(archivePath)
+	if err != nil {
+		log.Fatalf("failed to create archive: %v", err)
+	}
+	defer file.Close()
+	archive := zip.NewWriter(file)
+	add := func(path string, data []byte) {
+		w, err := archive.Create(path)
+		if err != nil {
+			log.Fatalf("failed to add %v to archive: %v", path, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			log.Fatalf("failed to add %v to archive: %v", path, err)
+		}
+		manifest.Files[path] = checksum(data)
+	}
+	marshal := func(m proto.Message) []byte {
+		data, err := proto.Marshal(m)
+		if err != nil {
+			log.Fatalf("failed to encode %T: %v", m, err)
+		}
+		return data
+	}
+
+	store := common.NewSQLiteChunkStore(db)
+	chunks := 0
+	for _, spec := range common.QueryPlanetSpecs(db) {
+		add(fmt.Sprintf("planets/%d.pb", spec.Id), marshal(spec))
+		for _, key := range store.List(spec.Id) {
+			add(fmt.Sprintf("chunks/%d/%d_%d_%d.pb", spec.Id, key.Lon, key.Lat, key.Alt), marshal(store.Load(spec.Id, key)))
+			chunks++
+		}
+	}
+	rows, err = db.Query("SELECT name, data FROM entity")
+	if err != nil {
+		log.Fatalf("failed to read entities: %v", err)
+	}
+	for rows.Next() {
+		var name string
+		var data []byte
+		if err := rows.Scan(&name, &data); err != nil {
+			log.Fatalf("failed to read entities: %v", err)
+		}
+		add("entities/"+url.PathEscape(name)+".bin", data)
+	}
+	rows.Close()
+
+	w, err := archive.Create(archiveManifest)
+	if err != nil {
+		log.Fatalf("failed to add manifest to archive: %v", err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		log.Fatalf("failed to write manifest: %v", err)
+	}
+	if err := archive.Close(); err != nil {
+		log.Fatalf("failed to write archive: %v", err)
+	}
+	log.Printf("exported %v with %v stored chunks to %v", name, chunks, archivePath)
+}
+
+// readArchive returns the manifest of a world archive and its files, once every file matches the checksum in the manifest
+func readArchive(archivePath string) (worldManifest, map[string][]byte, error) {
+	var manifest worldManifest
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return manifest, nil, err
+	}
+	defer archive.Close()
+	files := map[string][]byte{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			return manifest, nil, err
+		}
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return manifest, nil, fmt.Errorf("failed to read %v: %v", f.Name, err)
+		}
+		files[f.Name] = data
+	}
+
+	data, ok := files[archiveManifest]
+	if !ok {
+		return manifest, nil, fmt.Errorf("no manifest")
+	}
+	delete(files, archiveManifest)
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, nil, fmt.Errorf("bad manifest: %v", err)
+	}
+	if manifest.Format != archiveFormat {
+		return manifest, nil, fmt.Errorf("not a world archive")
+	}
+	if manifest.Version > archiveVersion {
+		return manifest, nil, fmt.Errorf("archive version %d is newer than this game's %d", manifest.Version, archiveVersion)
+	}
+	for path, sum := range manifest.Files {
+		data, ok := files[path]
+		if !ok {
+			return manifest, nil, fmt.Errorf("missing %v", path)
+		}
+		if checksum(data) != sum {
+			return manifest, nil, fmt.Errorf("checksum mismatch for %v", path)
+		}
+	}
+	for path := range files {
+		if _, ok := manifest.Files[path]; !ok {
+			return manifest, nil, fmt.Errorf("%v is not in the manifest", path)
+		}
+	}
+	return manifest, files, nil
+}
+
+// ImportWorld creates a world from an archive, once every file in the archive is checked
+func ImportWorld(archivePath, name string) {
+	dbName := "worlds/" + name + ".db"
+	if _, err := os.Stat(dbName); err == nil {
+		log.Fatalf("world %v already exists", name)
+	}
+	manifest, files, err := readArchive(archivePath)
+	if err != nil {
+		log.Fatalf("archive %v cannot be imported: %v", archivePath, err)
+	}
+
+	// Build the world beside where it goes, so a failed import leaves nothing behind
+	_ = os.Mkdir("worlds/", os.ModePerm)
+	importName := dbName + ".import"
+	os.Remove(importName)
+	db, _ := openWorld(importName, manifest.Seed)
+	fail := func(format string, v ...interface{}) {
+		db.Close()
+		os.Remove(importName)
+		log.Fatalf(format, v...)
+	}
+	for key, value := range manifest.Metadata {
+		if archiveMetadataSkipped[key] || key == "gameVersion" {
+			continue
+		}
+		if err := setMetadata(db, key, value); err != nil {
+			fail("failed to store metadata: %v", err)
+		}
+	}
+
+	paths := []string{}
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	chunks := map[int64]map[common.ChunkKey]*pb.Chunk{}
+	for _, path := range paths {
+		data := files[path]
+		switch {
+		case strings.HasPrefix(path, "planets/"):
+			var spec pb.PlanetSpec
+			if err := proto.Unmarshal(data, &spec); err != nil {
+				fail("bad planet %v: %v", path, err)
+			}
+			if err := common.ValidatePlanetSpec(&spec); err != nil {
+				fail("bad planet %v: %v", path, err)
+			}
+			common.SavePlanetSpec(db, spec)
+		case strings.HasPrefix(path, "chunks/"):
+			var planet int64
+			var key common.ChunkKey
+			var rest string
+			n, _ := fmt.Sscanf(strings.Replace(path, "/", " ", -1), "chunks %d %d_%d_%d%s", &planet, &key.Lon, &key.Lat, &key.Alt, &rest)
+			if n != 5 || rest != ".pb" {
+				fail("bad chunk path %v", path)
+			}
+			var chunk pb.Chunk
+			if err := proto.Unmarshal(data, &chunk); err != nil {
+				fail("bad chunk %v: %v", path, err)
+			}
+			if chunks[planet] == nil {
+				chunks[planet] = map[common.ChunkKey]*pb.Chunk{}
+			}
+			chunks[planet][key] = &chunk
+		case strings.HasPrefix(path, "entities/") && strings.HasSuffix(path, ".bin"):
+			entity, err := url.PathUnescape(strings.TrimSuffix(strings.TrimPrefix(path, "entities/"), ".bin"))
+			if err != nil {
+				fail("bad entity path %v: %v", path, err)
+			}
+			if _, err := db.Exec("INSERT INTO entity VALUES (?, ?)", entity, data); err != nil {
+				fail("failed to store entity: %v", err)
+			}
+		default:
+			fail("unknown file %v", path)
+		}
+	}
+	store := common.NewSQLiteChunkStore(db)
+	for planet, batch := range chunks {
+		store.SaveBatch(planet, batch)
+	}
+	db.Close()
+
+	if err := os.Rename(importName, dbName); err != nil {
+		log.Fatalf("failed to import world: %v", err)
+	}
+	log.Printf("imported %v with seed %v from %v", name, manifest.Seed, archivePath)
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jeffbaumes/govox/pkg/common"
+)
+
+var (
+	worldName   string
+	worldDB     *sql.DB
+	backupMutex sync.Mutex
+)
+
+// backupDir returns the directory holding the backups of a world
+func backupDir(name string) string {
+	return filepath.Join("backups", name)
+}
+
+// replacedDir returns the directory holding the worlds replaced by restoring a backup, which are never pruned
+func replacedDir(name string) string {
+	return filepath.Join(backupDir(name), "replaced")
+}
+
+// backupWorld snapshots the world being served into its backup directory, returning the path of the snapshot
+func backupWorld() (string, error) {
+	backupMutex.Lock()
+	defer backupMutex.Unlock()
+	if worldDB == nil {
+		return "", fmt.Errorf("no world is being served")
+	}
+
+	// Save the edits so far, so the snapshot has them
+	flusher.Flush()
+
+	dir := backupDir(worldName)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, time.Now().UTC().Format("20060102-150405.000")+".db")
+
+	// VACUUM INTO reads the database in one transaction, so the snapshot is consistent even as chunks are saved
+	if _, err := worldDB.Exec("VACUUM INTO ?", path); err != nil {
+		return "", err
+	}
+	pruneBackups(dir, options.BackupRetention)
+	return path, nil
+}
+
+// pruneBackups deletes all but the newest backups in a directory
+func pruneBackups(dir string, keep int) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.db"))
+	if err != nil {
+		log.Printf("failed to list backups: %v", err)
+		return
+	}
+	// Backups are named by time, so they sort oldest first
+	sort.Strings(names)
+	for len(names) > keep {
+		if err := os.Remove(names[0]); err != nil {
+			log.Printf("failed to delete backup: %v", err)
+		}
+		names = names[1:]
+	}
+}
+
+// scheduleBackups backs up the world being served at the interval in its options
+func scheduleBackups() {
+	if options.BackupInterval <= 0 {
+		return
+	}
+	go func() {
+		for range time.Tick(options.BackupInterval) {
+			path, err := backupWorld()
+			if err != nil {
+				log.Printf("failed to back up world: %v", err)
+				continue
+			}
+			log.Printf("backed up world to %v", path)
+		}
+	}()
+}
+
+// validateWorld checks that a world database is intact and holds data this game can read
+func validateWorld(dbName string) (err error) {
+	db, err := sql.Open("sqlite3", dbName)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var integrity string
+	if err := db.QueryRow("PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return err
+	}
+	if integrity != "ok" {
+		return fmt.Errorf("integrity check failed: %v", integrity)
+	}
+
+	value, ok, err := getMetadata(db, "schemaVersion")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("no schema version")
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("bad schema version %q: %v", value, err)
+	}
+	if version > len(worldMigrations) {
+		return fmt.Errorf("schema version %d is newer than this game's %d", version, len(worldMigrations))
+	}
+
+	// Reading the world panics on bad data, so read all of it
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("bad world data: %v", r)
+		}
+	}()
+	if err := migrateWorld(db); err != nil {
+		return err
+	}
+	var planets int
+	if err := db.QueryRow("SELECT count(*) FROM planet").Scan(&planets); err != nil {
+		return err
+	}
+	if planets == 0 {
+		return fmt.Errorf("no planets")
+	}
+	store := common.NewSQLiteChunkStore(db)
+	u, err := common.NewUniverse(db, store, "", 0)
+	if err != nil {
+		return err
+	}
+	for id := range u.PlanetMap {
+		for _, key := range store.List(id) {
+			store.Load(id, key)
+		}
+	}
+	return nil
+}
+
+// copyFile copies a file, replacing any file at the destination
+func copyFile(from, to string) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Restore replaces a world with one of its backups, once the backup is checked.
+// The world being replaced is kept apart from its backups, so pruning them never deletes it.
+// The world should not be served while it is restored.
+func Restore(name, backup string) {
+	dbName := "worlds/" + name + ".db"
+	restoreName := dbName + ".restore"
+
+	// Check a copy, since checking may migrate it
+	if err := copyFile(backup, restoreName); err != nil {
+		log.Fatalf("failed to copy backup: %v", err)
+	}
+	if err := validateWorld(restoreName); err != nil {
+		os.Remove(restoreName)
+		log.Fatalf("backup %v cannot be restored: %v", backup, err)
+	}
+
+	if _, err := os.Stat(dbName); err == nil {
+		dir := replacedDir(name)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			log.Fatalf("failed to create replaced world directory: %v", err)
+		}
+		replaced := filepath.Join(dir, time.Now().UTC().Format("20060102-150405.000")+".db")
+		if err := os.Rename(dbName, replaced); err != nil {
+			log.Fatalf("failed to keep replaced world: %v", err)
+		}
+		log.Printf("kept replaced world as %v", replaced)
+	}
+	if err := os.Rename(restoreName, dbName); err != nil {
+		log.Fatalf("failed to restore world: %v", err)
+	}
+	log.Printf("restored %v from %v", name, backup)
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPruneBackupsKeepsReplacedWorlds(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	replaced := filepath.Join(dir, "replaced")
+	if err := os.MkdirAll(replaced, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	// The replaced world is older than every backup
+	files := []string{
+		filepath.Join(replaced, "20200101-000000.000.db"),
+		filepath.Join(dir, "20200102-000000.000.db"),
+		filepath.Join(dir, "20200103-000000.000.db"),
+		filepath.Join(dir, "20200104-000000.000.db"),
+	}
+	for _, f := range files {
+		if err := ioutil.WriteFile(f, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pruneBackups(dir, 2)
+	for i, f := range files {
+		_, err := os.Stat(f)
+		if kept, want := err == nil, i != 1; kept != want {
+			t.Errorf("%v kept %v, want %v", f, kept, want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"log"
+	"os"
+
+	"github.com/jeffbaumes/govox/pkg/common"
+)
+
+// Prune deletes the stored chunks of a world that match the terrain generated in their place.
+// Only chunks change, so the world keeps its schema and metadata. The world should not be served while it is pruned.
+func Prune(name string) {
+	dbName := "worlds/" + name + ".db"
+	if _, err := os.Stat(dbName); err != nil {
+		log.Fatalf("failed to find world: %v", err)
+	}
+	db, seed := openWorldInPlace(dbName, false)
+	defer db.Close()
+	u, err := common.NewUniverse(db, common.NewSQLiteChunkStore(db), "", seed)
+	if err != nil {
+		log.Fatalf("failed to load %v: %v", dbName, err)
+	}
+	for id, planet := range u.PlanetMap {
+		pruned := planet.PruneChunks()
+		log.Printf("pruned %v chunks from planet %v", pruned, id)
+	}
+	if _, err := db.Exec("VACUUM"); err != nil {
+		log.Fatalf("failed to vacuum database: %v", err)
+	}
+}
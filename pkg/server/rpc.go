@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"errors"
+	"log"
 
 	pb "github.com/jeffbaumes/govox/pkg/govox"
 )
@@ -82,6 +83,46 @@ func (s *server) HitPlayer(ctx context.Context, in *pb.HitPlayerRequest) (*pb.Hi
 	return &pb.HitPlayerResponse{}, nil
 }
 
+// Backup snapshots the world into its backup directory
+func (s *server) Backup(ctx context.Context, in *pb.BackupRequest) (*pb.BackupResponse, error) {
+	path, err := backupWorld()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("backed up world to %v", path)
+	return &pb.BackupResponse{Path: path}, nil
+}
+
+// PlaceStructure sets the cells covered by a structure in one batch
+func (s *server) PlaceStructure(ctx context.Context, in *pb.PlaceStructureRequest) (*pb.PlaceStructureResponse, error) {
+	planet := universe.PlanetMap[in.Planet]
+	if planet == nil {
+		return nil, errors.New("unknown planet ID")
+	}
+	if in.Origin == nil {
+		return nil, errors.New("no origin cell")
+	}
+	changed, err := planet.PlaceStructure(in.Voxels, *in.Origin, in.Turns)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("placed a structure of %v voxels on planet %v, changing %v cells", len(in.Voxels), in.Planet, changed)
+	return &pb.PlaceStructureResponse{Changed: int64(changed)}, nil
+}
+
+// GetSunElevation returns the elevation of the system's star above the horizon at a cell of a planet
+func (s *server) GetSunElevation(ctx context.Context, in *pb.GetSunElevationRequest) (*pb.GetSunElevationResponse, error) {
+	planet := universe.PlanetMap[in.Planet]
+	if planet == nil {
+		return nil, errors.New("unknown planet ID")
+	}
+	if in.Index == nil {
+		return nil, errors.New("no cell")
+	}
+	elevation, hasSun := universe.SunElevation(in.Planet, planet.CellIndexToCellLoc(*in.Index), in.Time)
+	return &pb.GetSunElevationResponse{Elevation: elevation, HasSun: hasSun}, nil
+}
+
 // SendText sends a text to all players
 func (s *server) SendText(ctx context.Context, in *pb.SendTextRequest) (*pb.SendTextResponse, error) {
 	// var validPeople []*connectedPerson
@@ -1,11 +1,14 @@
 package server
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/jeffbaumes/govox/pkg/common"
 	pb "github.com/jeffbaumes/govox/pkg/govox"
@@ -14,42 +17,54 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
+// Options are the settings of a server that are not part of the world it serves
+type Options struct {
+	// SaveLatency bounds how long a changed chunk waits in memory before it is saved to the world database
+	SaveLatency time.Duration
+
+	// BackupInterval is how often the world being served is backed up, or never if zero
+	BackupInterval time.Duration
+
+	// BackupRetention is how many backups of a world are kept, the oldest being deleted first
+	BackupRetention int
+}
+
+// DefaultOptions are the settings of a server that is not told otherwise
+var DefaultOptions = Options{
+	SaveLatency:     5 * time.Second,
+	BackupInterval:  time.Hour,
+	BackupRetention: 24,
+}
+
 var (
 	universe *common.Universe
+	options  Options
+
+	grpcServer *grpc.Server
+	flusher    *common.ChunkFlusher
+	stopMutex  sync.Mutex
 )
 
-// Start takes a name, seed, and port and starts the universe server
-func Start(name string, seed, port int, system string) {
+// Start takes a name, seed, port and options and starts the universe server
+func Start(name string, seed, port int, system string, opts Options) {
+	if opts.BackupRetention < 1 {
+		log.Fatalf("backup retention of %v would delete every backup as soon as it is made", opts.BackupRetention)
+	}
+	options = opts
 	_ = os.Mkdir("worlds/", os.ModePerm)
+	migrateWorlds("worlds/")
 	dbName := "worlds/" + name + ".db"
 
-	db, err := sql.Open("sqlite3", dbName)
-	if err != nil {
-		log.Fatalf("failed to open database: %v", err)
-	}
-	stmt, err := db.Prepare("CREATE TABLE IF NOT EXISTS chunk (planet INT, lon INT, lat INT, alt INT, data BLOB, PRIMARY KEY (planet, lat, lon, alt))")
-	if err != nil {
-		log.Fatalf("failed to create chunk table statement: %v", err)
-	}
-	if _, err := stmt.Exec(); err != nil {
-		log.Fatalf("failed to create chunk table: %v", err)
-	}
-	stmt, err = db.Prepare("CREATE TABLE IF NOT EXISTS planet (id INT PRIMARY KEY, data BLOB)")
-	if err != nil {
-		log.Fatalf("failed to create planet table statement: %v", err)
-	}
-	if _, err := stmt.Exec(); err != nil {
-		log.Fatalf("failed to create planet table: %v", err)
-	}
-	stmt, err = db.Prepare("CREATE TABLE IF NOT EXISTS entity (name TEXT PRIMARY KEY, data BLOB)")
+	db, worldSeed := openWorld(dbName, int64(seed))
+
+	u, err := common.NewUniverse(db, common.NewSQLiteChunkStore(db), system, worldSeed)
 	if err != nil {
-		log.Fatalf("failed to create entity table statement: %v", err)
-	}
-	if _, err := stmt.Exec(); err != nil {
-		log.Fatalf("failed to create entity table: %v", err)
+		log.Fatalf("failed to load %v: %v", dbName, err)
 	}
-
-	universe = common.NewUniverse(db, system)
+	universe = u
+	defer db.Close()
+	worldName = name
+	worldDB = db
 
 	// // Connect to generator
 	// conn, err := grpc.Dial("localhost:50052", grpc.WithInsecure())
@@ -73,9 +88,36 @@ func Start(name string, seed, port int, system string) {
 	s := grpc.NewServer()
 	pb.RegisterGovoxServer(s, &server{})
 	reflection.Register(s)
+	stopMutex.Lock()
+	grpcServer = s
+	flusher = common.NewChunkFlusher(universe, options.SaveLatency)
+	stopMutex.Unlock()
+	scheduleBackups()
+
+	// Save the world before going down
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		Stop()
+		os.Exit(0)
+	}()
+
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+	Stop()
+}
+
+// Stop stops the server, returning once every changed chunk is saved
+func Stop() {
+	stopMutex.Lock()
+	defer stopMutex.Unlock()
+	if grpcServer == nil {
+		return
+	}
+	grpcServer.Stop()
+	flusher.Close()
 }
 
 // // Start takes a name, seed, and port and starts the universe server
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/jeffbaumes/govox/pkg/common"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+// ExportVox writes the cells of a world within radius cells of a center cell to a MagicaVoxel .vox file.
+// It reads the world as last saved without writing to it, so edits a running server has not flushed yet are left out.
+func ExportVox(name string, planetID int64, center pb.CellIndex, radius int64, voxPath string) {
+	dbName := "worlds/" + name + ".db"
+	if _, err := os.Stat(dbName); err != nil {
+		log.Fatalf("failed to find world: %v", err)
+	}
+	db, seed := openWorldInPlace(dbName, true)
+	defer db.Close()
+	u, err := common.NewUniverse(db, common.NewSQLiteChunkStore(db), "", seed)
+	if err != nil {
+		log.Fatalf("failed to load %v: %v", dbName, err)
+	}
+	planet, ok := u.PlanetMap[planetID]
+	if !ok {
+		log.Fatalf("world %v has no planet %v", name, planetID)
+	}
+	if planet.CellIndexToCell(center) == nil {
+		log.Fatalf("cell %v,%v,%v is not on planet %v", center.Lon, center.Lat, center.Alt, planetID)
+	}
+	var buf bytes.Buffer
+	if err := planet.WriteVox(&buf, center, radius, radius, radius); err != nil {
+		log.Fatalf("failed to export region: %v", err)
+	}
+	if err := ioutil.WriteFile(voxPath, buf.Bytes(), 0644); err != nil {
+		log.Fatalf("failed to write %v: %v", voxPath, err)
+	}
+	log.Printf("exported cells within %v of %v,%v,%v on planet %v to %v", radius, center.Lon, center.Lat, center.Alt, planetID, voxPath)
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jeffbaumes/govox/pkg/common"
+)
+
+// GameVersion is the version of the game, recorded in the worlds it opens
+const GameVersion = "0.2.0"
+
+// worldMigrations upgrade a world database one schema version at a time.
+// A world at schema version n has had the first n applied, so new migrations only ever go on the end.
+var worldMigrations = []func(tx *sql.Tx) error{
+	// 1: the tables of worlds made before there were schema versions
+	createWorldTables,
+	// 2: chunks and planet specs stored as versioned protobuf instead of gob
+	func(tx *sql.Tx) error { return common.MigrateLegacyBlobs(tx) },
+}
+
+func createWorldTables(tx *sql.Tx) error {
+	for _, table := range []string{
+		"chunk (planet INT, lon INT, lat INT, alt INT, data BLOB, PRIMARY KEY (planet, lat, lon, alt))",
+		"planet (id INT PRIMARY KEY, data BLOB)",
+		"entity (name TEXT PRIMARY KEY, data BLOB)",
+	} {
+		if _, err := tx.Exec("CREATE TABLE IF NOT EXISTS " + table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type metadataQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+type metadataExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// getMetadata returns a value from the metadata table of a world, and whether it was there
+func getMetadata(db metadataQueryer, key string) (string, bool, error) {
+	var value string
+	err := db.QueryRow("SELECT value FROM metadata WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// setMetadata stores a value in the metadata table of a world
+func setMetadata(db metadataExecer, key, value string) error {
+	_, err := db.Exec("INSERT OR REPLACE INTO metadata VALUES (?, ?)", key, value)
+	return err
+}
+
+// migrateWorld brings a world database up to the schema version of this game, refusing worlds from a newer one
+func migrateWorld(db *sql.DB) error {
+	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS metadata (key TEXT PRIMARY KEY, value TEXT)"); err != nil {
+		return err
+	}
+	version := 0
+	value, ok, err := getMetadata(db, "schemaVersion")
+	if err != nil {
+		return err
+	}
+	if ok {
+		version, err = strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("bad schema version %q: %v", value, err)
+		}
+	}
+	if version > len(worldMigrations) {
+		return fmt.Errorf("world has schema version %d, but this game only knows up to %d", version, len(worldMigrations))
+	}
+	for ; version < len(worldMigrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if err := worldMigrations[version](tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to migrate to schema version %d: %v", version+1, err)
+		}
+		if err := setMetadata(tx, "schemaVersion", strconv.Itoa(version+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openWorldInPlace opens an existing world for a tool that works on it where it lies, leaving its schema and metadata
+// as they are. Since the world is neither migrated nor generated, it must be at this game's schema version and have
+// planets. A read-only world cannot be written at all.
+func openWorldInPlace(dbName string, readOnly bool) (*sql.DB, int64) {
+	dsn := dbName
+	if readOnly {
+		dsn = "file:" + dbName + "?mode=ro"
+	}
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	value, ok, err := getMetadata(db, "schemaVersion")
+	if err != nil || !ok {
+		log.Fatalf("%v has no schema version, so serve it once to migrate it", dbName)
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("bad schema version %q: %v", value, err)
+	}
+	if version < len(worldMigrations) {
+		log.Fatalf("%v has schema version %d, older than this game's %d, so serve it once to migrate it", dbName, version, len(worldMigrations))
+	}
+	if version > len(worldMigrations) {
+		log.Fatalf("%v has schema version %d, newer than this game's %d", dbName, version, len(worldMigrations))
+	}
+	var planets int
+	if err := db.QueryRow("SELECT count(*) FROM planet").Scan(&planets); err != nil {
+		log.Fatalf("failed to read planets: %v", err)
+	}
+	if planets == 0 {
+		log.Fatalf("%v has no planets", dbName)
+	}
+	seed := int64(0)
+	if value, ok, err := getMetadata(db, "seed"); err == nil && ok {
+		seed, _ = strconv.ParseInt(value, 10, 64)
+	}
+	return db, seed
+}
+
+// migrateWorlds brings every world in a directory up to the schema version of this game, skipping those from a newer one
+func migrateWorlds(dir string) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.db"))
+	if err != nil {
+		log.Fatalf("failed to list worlds: %v", err)
+	}
+	for _, dbName := range names {
+		db, err := sql.Open("sqlite3", dbName)
+		if err != nil {
+			log.Fatalf("failed to open database: %v", err)
+		}
+		if err := migrateWorld(db); err != nil {
+			log.Printf("failed to migrate %v: %v", dbName, err)
+		}
+		db.Close()
+	}
+}
+
+// openWorld opens a world database at the schema version of this game.
+// A new world records the seed it is given, and an existing world returns the seed it was made with.
+func openWorld(dbName string, seed int64) (*sql.DB, int64) {
+	db, err := sql.Open("sqlite3", dbName)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	if err := migrateWorld(db); err != nil {
+		log.Fatalf("failed to open %v: %v", dbName, err)
+	}
+	value, ok, err := getMetadata(db, "seed")
+	if err != nil {
+		log.Fatalf("failed to read world seed: %v", err)
+	}
+	if ok {
+		seed, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Fatalf("bad world seed %q: %v", value, err)
+		}
+	} else {
+		// Worlds from before there was metadata count as created when first opened with it
+		if err := setMetadata(db, "seed", strconv.FormatInt(seed, 10)); err != nil {
+			log.Fatalf("failed to store world seed: %v", err)
+		}
+		if err := setMetadata(db, "created", time.Now().UTC().Format(time.RFC3339)); err != nil {
+			log.Fatalf("failed to store world creation time: %v", err)
+		}
+	}
+	if err := setMetadata(db, "gameVersion", GameVersion); err != nil {
+		log.Fatalf("failed to store game version: %v", err)
+	}
+	return db, seed
+}
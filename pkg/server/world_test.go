@@ -0,0 +1,73 @@
+package server
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jeffbaumes/govox/pkg/common"
+	pb "github.com/jeffbaumes/govox/pkg/govox"
+)
+
+func TestMigrateWorldCorruptBlob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "worlds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "corrupt.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// A world from before there were schema versions, with a chunk that is not a gob
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := createWorldTables(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO chunk VALUES (0, 0, 0, 0, ?)", []byte("not a gob")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrateWorld(db); err == nil {
+		t.Fatal("migrated a world with a corrupt chunk")
+	}
+	if version, _, err := getMetadata(db, "schemaVersion"); err != nil || version != "1" {
+		t.Errorf("schema version after a failed migration is %q, %v, want 1", version, err)
+	}
+	var data []byte
+	if err := db.QueryRow("SELECT data FROM chunk").Scan(&data); err != nil || string(data) != "not a gob" {
+		t.Errorf("chunk after a failed migration is %q, %v", data, err)
+	}
+}
+
+func TestNewUniverseBadPlanetSpec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "worlds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "bad.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := migrateWorld(db); err != nil {
+		t.Fatal(err)
+	}
+
+	// A stored planet whose chunks could not split in half is refused instead of loaded
+	common.SavePlanetSpec(db, pb.PlanetSpec{Id: 0, Radius: 64, AltCells: 64, ChunkSize: 24})
+	if _, err := common.NewUniverse(db, common.NewMemoryChunkStore(), "", 0); err == nil {
+		t.Error("loaded a planet with a chunk size of 24")
+	}
+}
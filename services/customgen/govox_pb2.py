@@ -20,7 +20,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   package='govox',
   syntax='proto3',
   serialized_options=None,
-  serialized_pb=_b('\n\x0bgovox.proto\x12\x05govox\"\x13\n\x11GetPlanetsRequest\"8\n\x12GetPlanetsResponse\x12\"\n\x07planets\x18\x01 \x03(\x0b\x32\x11.govox.PlanetSpec\"\xc8\x01\n\nPlanetSpec\x12\n\n\x02id\x18\x01 \x01(\x03\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0e\n\x06radius\x18\x03 \x01(\x01\x12\x10\n\x08\x61ltCells\x18\x04 \x01(\x03\x12\x13\n\x0borbitPlanet\x18\x05 \x01(\x03\x12\x15\n\rorbitDistance\x18\x06 \x01(\x01\x12\x14\n\x0corbitSeconds\x18\x07 \x01(\x01\x12\x17\n\x0frotationSeconds\x18\x08 \x01(\x01\x12\x0c\n\x04seed\x18\t \x01(\x03\x12\x15\n\rgeneratorType\x18\n \x01(\t\"C\n\x0fGetChunkRequest\x12\x0e\n\x06planet\x18\x01 \x01(\x03\x12 \n\x05index\x18\x02 \x01(\x0b\x32\x11.govox.ChunkIndex\"3\n\nChunkIndex\x12\x0b\n\x03lat\x18\x01 \x01(\x03\x12\x0b\n\x03lon\x18\x02 \x01(\x03\x12\x0b\n\x03\x61lt\x18\x03 \x01(\x03\"/\n\x10GetChunkResponse\x12\x1b\n\x05\x63hunk\x18\x01 \x01(\x0b\x32\x0c.govox.Chunk\"\x98\x01\n\x05\x43hunk\x12\"\n\x04\x63\x65ll\x18\x01 \x03(\x0b\x32\x14.govox.Chunk.CellLat\x12\x16\n\x0ewaitingForData\x18\x02 \x01(\x08\x1a-\n\x07\x43\x65llLat\x12\"\n\x04\x63\x65ll\x18\x01 \x03(\x0b\x32\x14.govox.Chunk.CellAlt\x1a$\n\x07\x43\x65llAlt\x12\x19\n\x04\x63\x65ll\x18\x01 \x03(\x0b\x32\x0b.govox.Cell\"*\n\x18GetPlanetGeometryRequest\x12\x0e\n\x06planet\x18\x01 \x01(\x03\"D\n\x19GetPlanetGeometryResponse\x12\'\n\x08geometry\x18\x01 \x01(\x0b\x32\x15.govox.PlanetGeometry\"\xe0\x01\n\x0ePlanetGeometry\x12\x33\n\x08\x61ltitude\x18\x01 \x03(\x0b\x32!.govox.PlanetGeometry.AltitudeRow\x12\x33\n\x08material\x18\x02 \x03(\x0b\x32!.govox.PlanetGeometry.MaterialRow\x12\x11\n\tisLoading\x18\x03 \x01(\x08\x1a\x1f\n\x0b\x41ltitudeRow\x12\x10\n\x08\x61ltitude\x18\x01 \x03(\x03\x1a\x30\n\x0bMaterialRow\x12!\n\x08material\x18\x01 \x03(\x0e\x32\x0f.govox.Material\"d\n\x16SetCellMaterialRequest\x12\x0e\n\x06planet\x18\x01 \x01(\x03\x12\x1f\n\x05index\x18\x02 \x01(\x0b\x32\x10.govox.CellIndex\x12\x19\n\x04\x63\x65ll\x18\x03 \x01(\x0b\x32\x0b.govox.Cell\")\n\x04\x43\x65ll\x12!\n\x08material\x18\x01 \x01(\x0e\x32\x0f.govox.Material\"2\n\tCellIndex\x12\x0b\n\x03lat\x18\x01 \x01(\x03\x12\x0b\n\x03lon\x18\x02 \x01(\x03\x12\x0b\n\x03\x61lt\x18\x03 \x01(\x03\"0\n\x07\x43\x65llLoc\x12\x0b\n\x03lat\x18\x01 \x01(\x01\x12\x0b\n\x03lon\x18\x02 \x01(\x01\x12\x0b\n\x03\x61lt\x18\x03 \x01(\x01\"\x19\n\x17SetCellMaterialResponse\"\x1f\n\x0fSendTextRequest\x12\x0c\n\x04text\x18\x01 \x01(\t\"\x12\n\x10SendTextResponse\"K\n\x18UpdatePlayerStateRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x10\n\x08position\x18\x02 \x03(\x01\x12\x0f\n\x07lookDir\x18\x03 \x03(\x01\"\x1b\n\x19UpdatePlayerStateResponse\"@\n\x10HitPlayerRequest\x12\x0c\n\x04\x66rom\x18\x01 \x01(\t\x12\x0e\n\x06target\x18\x02 \x01(\t\x12\x0e\n\x06\x61mount\x18\x03 \x01(\x03\"\x13\n\x11HitPlayerResponse\"Y\n\x13\x43\x65llMaterialRequest\x12\x1f\n\x05index\x18\x01 \x01(\x0b\x32\x10.govox.CellIndex\x12!\n\x06planet\x18\x02 \x01(\x0b\x32\x11.govox.PlanetSpec\"1\n\x14\x43\x65llMaterialResponse\x12\x19\n\x04\x63\x65ll\x18\x01 \x01(\x0b\x32\x0b.govox.Cell*\xe1\x01\n\x08Material\x12\x07\n\x03\x41IR\x10\x00\x12\t\n\x05GRASS\x10\x01\x12\x08\n\x04\x44IRT\x10\x02\x12\t\n\x05STONE\x10\x03\x12\x08\n\x04MOON\x10\x04\x12\x0c\n\x08\x41STEROID\x10\x05\x12\x07\n\x03SUN\x10\x06\x12\x0e\n\nBLUE_BLOCK\x10\x07\x12\r\n\tBLUE_SAND\x10\x08\x12\x10\n\x0cPURPLE_BLOCK\x10\t\x12\x0f\n\x0bPURPLE_SAND\x10\n\x12\r\n\tRED_BLOCK\x10\x0b\x12\x0c\n\x08RED_SAND\x10\x0c\x12\x10\n\x0cYELLOW_BLOCK\x10\r\x12\x0f\n\x0bYELLOW_SAND\x10\x0e\x12\t\n\x05WATER\x10\x0f\x32\x94\x04\n\x05Govox\x12\x43\n\nGetPlanets\x12\x18.govox.GetPlanetsRequest\x1a\x19.govox.GetPlanetsResponse\"\x00\x12=\n\x08GetChunk\x12\x16.govox.GetChunkRequest\x1a\x17.govox.GetChunkResponse\"\x00\x12X\n\x11GetPlanetGeometry\x12\x1f.govox.GetPlanetGeometryRequest\x1a .govox.GetPlanetGeometryResponse\"\x00\x12R\n\x0fSetCellMaterial\x12\x1d.govox.SetCellMaterialRequest\x1a\x1e.govox.SetCellMaterialResponse\"\x00\x12=\n\x08SendText\x12\x16.govox.SendTextRequest\x1a\x17.govox.SendTextResponse\"\x00\x12X\n\x11UpdatePlayerState\x12\x1f.govox.UpdatePlayerStateRequest\x1a .govox.UpdatePlayerStateResponse\"\x00\x12@\n\tHitPlayer\x12\x17.govox.HitPlayerRequest\x1a\x18.govox.HitPlayerResponse\"\x00\x32V\n\tGenerator\x12I\n\x0c\x43\x65llMaterial\x12\x1a.govox.CellMaterialRequest\x1a\x1b.govox.CellMaterialResponse\"\x00\x62\x06proto3')
+  serialized_pb=_b('\n\x0bgovox.proto\x12\x05govox\"\x13\n\x11GetPlanetsRequest\"8\n\x12GetPlanetsResponse\x12\"\n\x07planets\x18\x01 \x03(\x0b\x32\x11.govox.PlanetSpec\"\xb4\x03\n\nPlanetSpec\x12\n\n\x02id\x18\x01 \x01(\x03\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0e\n\x06radius\x18\x03 \x01(\x01\x12\x10\n\x08\x61ltCells\x18\x04 \x01(\x03\x12\x13\n\x0borbitPlanet\x18\x05 \x01(\x03\x12\x15\n\rorbitDistance\x18\x06 \x01(\x01\x12\x14\n\x0corbitSeconds\x18\x07 \x01(\x01\x12\x17\n\x0frotationSeconds\x18\x08 \x01(\x01\x12\x0c\n\x04seed\x18\t \x01(\x03\x12\x15\n\rgeneratorType\x18\n \x01(\t\x12\x14\n\x0c\x65\x63\x63\x65ntricity\x18\x0b \x01(\x01\x12\x13\n\x0binclination\x18\x0c \x01(\x01\x12\x15\n\rascendingNode\x18\r \x01(\x01\x12\x12\n\norbitPhase\x18\x0e \x01(\x01\x12\x11\n\taxialTilt\x18\x0f \x01(\x01\x12\x15\n\rtiltDirection\x18\x10 \x01(\x01\x12\x16\n\x0esurfaceGravity\x18\x11 \x01(\x01\x12\x19\n\x11\x61tmosphereDensity\x18\x12 \x01(\x01\x12\x10\n\x08topology\x18\x13 \x01(\t\x12\x11\n\tchunkSize\x18\x14 \x01(\x03\x12\x12\n\ncellHeight\x18\x15 \x01(\x01\"C\n\x0fGetChunkRequest\x12\x0e\n\x06planet\x18\x01 \x01(\x03\x12 \n\x05index\x18\x02 \x01(\x0b\x32\x11.govox.ChunkIndex\"3\n\nChunkIndex\x12\x0b\n\x03lat\x18\x01 \x01(\x03\x12\x0b\n\x03lon\x18\x02 \x01(\x03\x12\x0b\n\x03\x61lt\x18\x03 \x01(\x03\"/\n\x10GetChunkResponse\x12\x1b\n\x05\x63hunk\x18\x01 \x01(\x0b\x32\x0c.govox.Chunk\"\xcc\x01\n\x05\x43hunk\x12\"\n\x04\x63\x65ll\x18\x01 \x03(\x0b\x32\x14.govox.Chunk.CellLat\x12\x16\n\x0ewaitingForData\x18\x02 \x01(\x08\x12\x0f\n\x07uniform\x18\x03 \x01(\x08\x12!\n\x08material\x18\x04 \x01(\x0e\x32\x0f.govox.Material\x1a-\n\x07\x43\x65llLat\x12\"\n\x04\x63\x65ll\x18\x01 \x03(\x0b\x32\x14.govox.Chunk.CellAlt\x1a$\n\x07\x43\x65llAlt\x12\x19\n\x04\x63\x65ll\x18\x01 \x03(\x0b\x32\x0b.govox.Cell\"*\n\x18GetPlanetGeometryRequest\x12\x0e\n\x06planet\x18\x01 \x01(\x03\"D\n\x19GetPlanetGeometryResponse\x12\'\n\x08geometry\x18\x01 \x01(\x0b\x32\x15.govox.PlanetGeometry\"\xb8\x02\n\x0ePlanetGeometry\x12\x33\n\x08\x61ltitude\x18\x01 \x03(\x0b\x32!.govox.PlanetGeometry.AltitudeRow\x12\x33\n\x08material\x18\x02 \x03(\x0b\x32!.govox.PlanetGeometry.MaterialRow\x12\x11\n\tisLoading\x18\x03 \x01(\x08\x12-\n\x05\x62iome\x18\x04 \x03(\x0b\x32\x1e.govox.PlanetGeometry.BiomeRow\x1a\x1f\n\x0b\x41ltitudeRow\x12\x10\n\x08\x61ltitude\x18\x01 \x03(\x03\x1a\x30\n\x0bMaterialRow\x12!\n\x08material\x18\x01 \x03(\x0e\x32\x0f.govox.Material\x1a\'\n\x08\x42iomeRow\x12\x1b\n\x05\x62iome\x18\x01 \x03(\x0e\x32\x0c.govox.Biome\"d\n\x16SetCellMaterialRequest\x12\x0e\n\x06planet\x18\x01 \x01(\x03\x12\x1f\n\x05index\x18\x02 \x01(\x0b\x32\x10.govox.CellIndex\x12\x19\n\x04\x63\x65ll\x18\x03 \x01(\x0b\x32\x0b.govox.Cell\")\n\x04\x43\x65ll\x12!\n\x08material\x18\x01 \x01(\x0e\x32\x0f.govox.Material\"2\n\tCellIndex\x12\x0b\n\x03lat\x18\x01 \x01(\x03\x12\x0b\n\x03lon\x18\x02 \x01(\x03\x12\x0b\n\x03\x61lt\x18\x03 \x01(\x03\"0\n\x07\x43\x65llLoc\x12\x0b\n\x03lat\x18\x01 \x01(\x01\x12\x0b\n\x03lon\x18\x02 \x01(\x01\x12\x0b\n\x03\x61lt\x18\x03 \x01(\x01\"\x19\n\x17SetCellMaterialResponse\"\x1f\n\x0fSendTextRequest\x12\x0c\n\x04text\x18\x01 \x01(\t\"\x12\n\x10SendTextResponse\"K\n\x18UpdatePlayerStateRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x10\n\x08position\x18\x02 \x03(\x01\x12\x0f\n\x07lookDir\x18\x03 \x03(\x01\"\x1b\n\x19UpdatePlayerStateResponse\"@\n\x10HitPlayerRequest\x12\x0c\n\x04\x66rom\x18\x01 \x01(\t\x12\x0e\n\x06target\x18\x02 \x01(\t\x12\x0e\n\x06\x61mount\x18\x03 \x01(\x03\"\x13\n\x11HitPlayerResponse\"\x0f\n\rBackupRequest\"\x1e\n\x0e\x42\x61\x63kupResponse\x12\x0c\n\x04path\x18\x01 \x01(\t\"v\n\x15PlaceStructureRequest\x12\x0e\n\x06planet\x18\x01 \x01(\x03\x12 \n\x06origin\x18\x02 \x01(\x0b\x32\x10.govox.CellIndex\x12\r\n\x05turns\x18\x03 \x01(\x03\x12\x1c\n\x06voxels\x18\x04 \x03(\x0b\x32\x0c.govox.Voxel\"K\n\x05Voxel\x12\t\n\x01x\x18\x01 \x01(\x03\x12\t\n\x01y\x18\x02 \x01(\x03\x12\t\n\x01z\x18\x03 \x01(\x03\x12!\n\x08material\x18\x04 \x01(\x0e\x32\x0f.govox.Material\")\n\x16PlaceStructureResponse\x12\x0f\n\x07\x63hanged\x18\x01 \x01(\x03\"W\n\x16GetSunElevationRequest\x12\x0e\n\x06planet\x18\x01 \x01(\x03\x12\x1f\n\x05index\x18\x02 \x01(\x0b\x32\x10.govox.CellIndex\x12\x0c\n\x04time\x18\x03 \x01(\x01\"<\n\x17GetSunElevationResponse\x12\x11\n\televation\x18\x01 \x01(\x01\x12\x0e\n\x06hasSun\x18\x02 \x01(\x08\"Y\n\x13\x43\x65llMaterialRequest\x12\x1f\n\x05index\x18\x01 \x01(\x0b\x32\x10.govox.CellIndex\x12!\n\x06planet\x18\x02 \x01(\x0b\x32\x11.govox.PlanetSpec\"1\n\x14\x43\x65llMaterialResponse\x12\x19\n\x04\x63\x65ll\x18\x01 \x01(\x0b\x32\x0b.govox.Cell*\xee\x02\n\x08Material\x12\x07\n\x03\x41IR\x10\x00\x12\t\n\x05GRASS\x10\x01\x12\x08\n\x04\x44IRT\x10\x02\x12\t\n\x05STONE\x10\x03\x12\x08\n\x04MOON\x10\x04\x12\x0c\n\x08\x41STEROID\x10\x05\x12\x07\n\x03SUN\x10\x06\x12\x0e\n\nBLUE_BLOCK\x10\x07\x12\r\n\tBLUE_SAND\x10\x08\x12\x10\n\x0cPURPLE_BLOCK\x10\t\x12\x0f\n\x0bPURPLE_SAND\x10\n\x12\r\n\tRED_BLOCK\x10\x0b\x12\x0c\n\x08RED_SAND\x10\x0c\x12\x10\n\x0cYELLOW_BLOCK\x10\r\x12\x0f\n\x0bYELLOW_SAND\x10\x0e\x12\t\n\x05WATER\x10\x0f\x12\r\n\tBLUE_WOOD\x10\x10\x12\x0e\n\nGREEN_WOOD\x10\x11\x12\x0f\n\x0bPURPLE_WOOD\x10\x12\x12\x0f\n\x0bYELLOW_WOOD\x10\x13\x12\x0f\n\x0b\x42LUE_LEAVES\x10\x14\x12\x0c\n\x08\x43OAL_ORE\x10\x15\x12\x0c\n\x08IRON_ORE\x10\x16\x12\x0c\n\x08GOLD_ORE\x10\x17\x12\x0f\n\x0b\x43RYSTAL_ORE\x10\x18*w\n\x05\x42iome\x12\x0c\n\x08NO_BIOME\x10\x00\x12\t\n\x05OCEAN\x10\x01\x12\t\n\x05\x42\x45\x41\x43H\x10\x02\x12\r\n\tGRASSLAND\x10\x03\x12\n\n\x06\x46OREST\x10\x04\x12\n\n\x06\x44\x45SERT\x10\x05\x12\x0c\n\x08\x42\x41\x44LANDS\x10\x06\x12\n\n\x06TUNDRA\x10\x07\x12\t\n\x05POLAR\x10\x08\x32\xf2\x05\n\x05Govox\x12\x43\n\nGetPlanets\x12\x18.govox.GetPlanetsRequest\x1a\x19.govox.GetPlanetsResponse\"\x00\x12=\n\x08GetChunk\x12\x16.govox.GetChunkRequest\x1a\x17.govox.GetChunkResponse\"\x00\x12X\n\x11GetPlanetGeometry\x12\x1f.govox.GetPlanetGeometryRequest\x1a .govox.GetPlanetGeometryResponse\"\x00\x12R\n\x0fSetCellMaterial\x12\x1d.govox.SetCellMaterialRequest\x1a\x1e.govox.SetCellMaterialResponse\"\x00\x12=\n\x08SendText\x12\x16.govox.SendTextRequest\x1a\x17.govox.SendTextResponse\"\x00\x12X\n\x11UpdatePlayerState\x12\x1f.govox.UpdatePlayerStateRequest\x1a .govox.UpdatePlayerStateResponse\"\x00\x12@\n\tHitPlayer\x12\x17.govox.HitPlayerRequest\x1a\x18.govox.HitPlayerResponse\"\x00\x12\x37\n\x06\x42\x61\x63kup\x12\x14.govox.BackupRequest\x1a\x15.govox.BackupResponse\"\x00\x12O\n\x0ePlaceStructure\x12\x1c.govox.PlaceStructureRequest\x1a\x1d.govox.PlaceStructureResponse\"\x00\x12R\n\x0fGetSunElevation\x12\x1d.govox.GetSunElevationRequest\x1a\x1e.govox.GetSunElevationResponse\"\x00\x32V\n\tGenerator\x12I\n\x0c\x43\x65llMaterial\x12\x1a.govox.CellMaterialRequest\x1a\x1b.govox.CellMaterialResponse\"\x00\x62\x06proto3')
 )
 
 _MATERIAL = _descriptor.EnumDescriptor(
@@ -93,15 +93,102 @@ _MATERIAL = _descriptor.EnumDescriptor(
       name='WATER', index=15, number=15,
       serialized_options=None,
       type=None),
+    _descriptor.EnumValueDescriptor(
+      name='BLUE_WOOD', index=16, number=16,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='GREEN_WOOD', index=17, number=17,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='PURPLE_WOOD', index=18, number=18,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='YELLOW_WOOD', index=19, number=19,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='BLUE_LEAVES', index=20, number=20,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='COAL_ORE', index=21, number=21,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='IRON_ORE', index=22, number=22,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='GOLD_ORE', index=23, number=23,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='CRYSTAL_ORE', index=24, number=24,
+      serialized_options=None,
+      type=None),
   ],
   containing_type=None,
   serialized_options=None,
-  serialized_start=1634,
-  serialized_end=1859,
+  serialized_start=2450,
+  serialized_end=2816,
 )
 _sym_db.RegisterEnumDescriptor(_MATERIAL)
 
 Material = enum_type_wrapper.EnumTypeWrapper(_MATERIAL)
+_BIOME = _descriptor.EnumDescriptor(
+  name='Biome',
+  full_name='govox.Biome',
+  filename=None,
+  file=DESCRIPTOR,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='NO_BIOME', index=0, number=0,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='OCEAN', index=1, number=1,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='BEACH', index=2, number=2,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='GRASSLAND', index=3, number=3,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='FOREST', index=4, number=4,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='DESERT', index=5, number=5,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='BADLANDS', index=6, number=6,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='TUNDRA', index=7, number=7,
+      serialized_options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='POLAR', index=8, number=8,
+      serialized_options=None,
+      type=None),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=2818,
+  serialized_end=2937,
+)
+_sym_db.RegisterEnumDescriptor(_BIOME)
+
+Biome = enum_type_wrapper.EnumTypeWrapper(_BIOME)
 AIR = 0
 GRASS = 1
 DIRT = 2
@@ -118,6 +205,24 @@ RED_SAND = 12
 YELLOW_BLOCK = 13
 YELLOW_SAND = 14
 WATER = 15
+BLUE_WOOD = 16
+GREEN_WOOD = 17
+PURPLE_WOOD = 18
+YELLOW_WOOD = 19
+BLUE_LEAVES = 20
+COAL_ORE = 21
+IRON_ORE = 22
+GOLD_ORE = 23
+CRYSTAL_ORE = 24
+NO_BIOME = 0
+OCEAN = 1
+BEACH = 2
+GRASSLAND = 3
+FOREST = 4
+DESERT = 5
+BADLANDS = 6
+TUNDRA = 7
+POLAR = 8
 
 
 
@@ -253,6 +358,83 @@ _PLANETSPEC = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='eccentricity', full_name='govox.PlanetSpec.eccentricity', index=10,
+      number=11, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='inclination', full_name='govox.PlanetSpec.inclination', index=11,
+      number=12, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='ascendingNode', full_name='govox.PlanetSpec.ascendingNode', index=12,
+      number=13, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='orbitPhase', full_name='govox.PlanetSpec.orbitPhase', index=13,
+      number=14, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='axialTilt', full_name='govox.PlanetSpec.axialTilt', index=14,
+      number=15, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='tiltDirection', full_name='govox.PlanetSpec.tiltDirection', index=15,
+      number=16, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='surfaceGravity', full_name='govox.PlanetSpec.surfaceGravity', index=16,
+      number=17, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='atmosphereDensity', full_name='govox.PlanetSpec.atmosphereDensity', index=17,
+      number=18, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='topology', full_name='govox.PlanetSpec.topology', index=18,
+      number=19, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='chunkSize', full_name='govox.PlanetSpec.chunkSize', index=19,
+      number=20, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='cellHeight', full_name='govox.PlanetSpec.cellHeight', index=20,
+      number=21, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
@@ -266,7 +448,7 @@ _PLANETSPEC = _descriptor.Descriptor(
   oneofs=[
   ],
   serialized_start=102,
-  serialized_end=302,
+  serialized_end=538,
 )
 
 
@@ -303,8 +485,8 @@ _GETCHUNKREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=304,
-  serialized_end=371,
+  serialized_start=540,
+  serialized_end=607,
 )
 
 
@@ -348,8 +530,8 @@ _CHUNKINDEX = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=373,
-  serialized_end=424,
+  serialized_start=609,
+  serialized_end=660,
 )
 
 
@@ -379,8 +561,8 @@ _GETCHUNKRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=426,
-  serialized_end=473,
+  serialized_start=662,
+  serialized_end=709,
 )
 
 
@@ -410,8 +592,8 @@ _CHUNK_CELLLAT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=545,
-  serialized_end=590,
+  serialized_start=833,
+  serialized_end=878,
 )
 
 _CHUNK_CELLALT = _descriptor.Descriptor(
@@ -440,8 +622,8 @@ _CHUNK_CELLALT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=592,
-  serialized_end=628,
+  serialized_start=880,
+  serialized_end=916,
 )
 
 _CHUNK = _descriptor.Descriptor(
@@ -465,6 +647,20 @@ _CHUNK = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='uniform', full_name='govox.Chunk.uniform', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='material', full_name='govox.Chunk.material', index=3,
+      number=4, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
@@ -477,8 +673,8 @@ _CHUNK = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=476,
-  serialized_end=628,
+  serialized_start=712,
+  serialized_end=916,
 )
 
 
@@ -508,8 +704,8 @@ _GETPLANETGEOMETRYREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=630,
-  serialized_end=672,
+  serialized_start=918,
+  serialized_end=960,
 )
 
 
@@ -539,8 +735,8 @@ _GETPLANETGEOMETRYRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=674,
-  serialized_end=742,
+  serialized_start=962,
+  serialized_end=1030,
 )
 
 
@@ -570,8 +766,8 @@ _PLANETGEOMETRY_ALTITUDEROW = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=888,
-  serialized_end=919,
+  serialized_start=1223,
+  serialized_end=1254,
 )
 
 _PLANETGEOMETRY_MATERIALROW = _descriptor.Descriptor(
@@ -600,8 +796,38 @@ _PLANETGEOMETRY_MATERIALROW = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=921,
-  serialized_end=969,
+  serialized_start=1256,
+  serialized_end=1304,
+)
+
+_PLANETGEOMETRY_BIOMEROW = _descriptor.Descriptor(
+  name='BiomeRow',
+  full_name='govox.PlanetGeometry.BiomeRow',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='biome', full_name='govox.PlanetGeometry.BiomeRow.biome', index=0,
+      number=1, type=14, cpp_type=8, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1306,
+  serialized_end=1345,
 )
 
 _PLANETGEOMETRY = _descriptor.Descriptor(
@@ -632,10 +858,17 @@ _PLANETGEOMETRY = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='biome', full_name='govox.PlanetGeometry.biome', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
   ],
   extensions=[
   ],
-  nested_types=[_PLANETGEOMETRY_ALTITUDEROW, _PLANETGEOMETRY_MATERIALROW, ],
+  nested_types=[_PLANETGEOMETRY_ALTITUDEROW, _PLANETGEOMETRY_MATERIALROW, _PLANETGEOMETRY_BIOMEROW, ],
   enum_types=[
   ],
   serialized_options=None,
@@ -644,8 +877,8 @@ _PLANETGEOMETRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=745,
-  serialized_end=969,
+  serialized_start=1033,
+  serialized_end=1345,
 )
 
 
@@ -689,8 +922,8 @@ _SETCELLMATERIALREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=971,
-  serialized_end=1071,
+  serialized_start=1347,
+  serialized_end=1447,
 )
 
 
@@ -720,8 +953,8 @@ _CELL = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1073,
-  serialized_end=1114,
+  serialized_start=1449,
+  serialized_end=1490,
 )
 
 
@@ -765,8 +998,8 @@ _CELLINDEX = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1116,
-  serialized_end=1166,
+  serialized_start=1492,
+  serialized_end=1542,
 )
 
 
@@ -810,8 +1043,8 @@ _CELLLOC = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1168,
-  serialized_end=1216,
+  serialized_start=1544,
+  serialized_end=1592,
 )
 
 
@@ -834,8 +1067,8 @@ _SETCELLMATERIALRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1218,
-  serialized_end=1243,
+  serialized_start=1594,
+  serialized_end=1619,
 )
 
 
@@ -865,8 +1098,8 @@ _SENDTEXTREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1245,
-  serialized_end=1276,
+  serialized_start=1621,
+  serialized_end=1652,
 )
 
 
@@ -889,8 +1122,8 @@ _SENDTEXTRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1278,
-  serialized_end=1296,
+  serialized_start=1654,
+  serialized_end=1672,
 )
 
 
@@ -934,8 +1167,8 @@ _UPDATEPLAYERSTATEREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1298,
-  serialized_end=1373,
+  serialized_start=1674,
+  serialized_end=1749,
 )
 
 
@@ -958,8 +1191,8 @@ _UPDATEPLAYERSTATERESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1375,
-  serialized_end=1402,
+  serialized_start=1751,
+  serialized_end=1778,
 )
 
 
@@ -1003,8 +1236,8 @@ _HITPLAYERREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1404,
-  serialized_end=1468,
+  serialized_start=1780,
+  serialized_end=1844,
 )
 
 
@@ -1027,8 +1260,281 @@ _HITPLAYERRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1470,
-  serialized_end=1489,
+  serialized_start=1846,
+  serialized_end=1865,
+)
+
+
+_BACKUPREQUEST = _descriptor.Descriptor(
+  name='BackupRequest',
+  full_name='govox.BackupRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1867,
+  serialized_end=1882,
+)
+
+
+_BACKUPRESPONSE = _descriptor.Descriptor(
+  name='BackupResponse',
+  full_name='govox.BackupResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='path', full_name='govox.BackupResponse.path', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1884,
+  serialized_end=1914,
+)
+
+
+_PLACESTRUCTUREREQUEST = _descriptor.Descriptor(
+  name='PlaceStructureRequest',
+  full_name='govox.PlaceStructureRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='planet', full_name='govox.PlaceStructureRequest.planet', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='origin', full_name='govox.PlaceStructureRequest.origin', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='turns', full_name='govox.PlaceStructureRequest.turns', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='voxels', full_name='govox.PlaceStructureRequest.voxels', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1916,
+  serialized_end=2034,
+)
+
+
+_VOXEL = _descriptor.Descriptor(
+  name='Voxel',
+  full_name='govox.Voxel',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='x', full_name='govox.Voxel.x', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='y', full_name='govox.Voxel.y', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='z', full_name='govox.Voxel.z', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='material', full_name='govox.Voxel.material', index=3,
+      number=4, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2036,
+  serialized_end=2111,
+)
+
+
+_PLACESTRUCTURERESPONSE = _descriptor.Descriptor(
+  name='PlaceStructureResponse',
+  full_name='govox.PlaceStructureResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='changed', full_name='govox.PlaceStructureResponse.changed', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2113,
+  serialized_end=2154,
+)
+
+
+_GETSUNELEVATIONREQUEST = _descriptor.Descriptor(
+  name='GetSunElevationRequest',
+  full_name='govox.GetSunElevationRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='planet', full_name='govox.GetSunElevationRequest.planet', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='index', full_name='govox.GetSunElevationRequest.index', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='time', full_name='govox.GetSunElevationRequest.time', index=2,
+      number=3, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2156,
+  serialized_end=2243,
+)
+
+
+_GETSUNELEVATIONRESPONSE = _descriptor.Descriptor(
+  name='GetSunElevationResponse',
+  full_name='govox.GetSunElevationResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='elevation', full_name='govox.GetSunElevationResponse.elevation', index=0,
+      number=1, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='hasSun', full_name='govox.GetSunElevationResponse.hasSun', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2245,
+  serialized_end=2305,
 )
 
 
@@ -1065,8 +1571,8 @@ _CELLMATERIALREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1491,
-  serialized_end=1580,
+  serialized_start=2307,
+  serialized_end=2396,
 )
 
 
@@ -1096,8 +1602,8 @@ _CELLMATERIALRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1582,
-  serialized_end=1631,
+  serialized_start=2398,
+  serialized_end=2447,
 )
 
 _GETPLANETSRESPONSE.fields_by_name['planets'].message_type = _PLANETSPEC
@@ -1108,15 +1614,23 @@ _CHUNK_CELLLAT.containing_type = _CHUNK
 _CHUNK_CELLALT.fields_by_name['cell'].message_type = _CELL
 _CHUNK_CELLALT.containing_type = _CHUNK
 _CHUNK.fields_by_name['cell'].message_type = _CHUNK_CELLLAT
+_CHUNK.fields_by_name['material'].enum_type = _MATERIAL
 _GETPLANETGEOMETRYRESPONSE.fields_by_name['geometry'].message_type = _PLANETGEOMETRY
 _PLANETGEOMETRY_ALTITUDEROW.containing_type = _PLANETGEOMETRY
 _PLANETGEOMETRY_MATERIALROW.fields_by_name['material'].enum_type = _MATERIAL
 _PLANETGEOMETRY_MATERIALROW.containing_type = _PLANETGEOMETRY
+_PLANETGEOMETRY_BIOMEROW.fields_by_name['biome'].enum_type = _BIOME
+_PLANETGEOMETRY_BIOMEROW.containing_type = _PLANETGEOMETRY
 _PLANETGEOMETRY.fields_by_name['altitude'].message_type = _PLANETGEOMETRY_ALTITUDEROW
 _PLANETGEOMETRY.fields_by_name['material'].message_type = _PLANETGEOMETRY_MATERIALROW
+_PLANETGEOMETRY.fields_by_name['biome'].message_type = _PLANETGEOMETRY_BIOMEROW
 _SETCELLMATERIALREQUEST.fields_by_name['index'].message_type = _CELLINDEX
 _SETCELLMATERIALREQUEST.fields_by_name['cell'].message_type = _CELL
 _CELL.fields_by_name['material'].enum_type = _MATERIAL
+_PLACESTRUCTUREREQUEST.fields_by_name['origin'].message_type = _CELLINDEX
+_PLACESTRUCTUREREQUEST.fields_by_name['voxels'].message_type = _VOXEL
+_VOXEL.fields_by_name['material'].enum_type = _MATERIAL
+_GETSUNELEVATIONREQUEST.fields_by_name['index'].message_type = _CELLINDEX
 _CELLMATERIALREQUEST.fields_by_name['index'].message_type = _CELLINDEX
 _CELLMATERIALREQUEST.fields_by_name['planet'].message_type = _PLANETSPEC
 _CELLMATERIALRESPONSE.fields_by_name['cell'].message_type = _CELL
@@ -1141,9 +1655,17 @@ DESCRIPTOR.message_types_by_name['UpdatePlayerStateRequest'] = _UPDATEPLAYERSTAT
 DESCRIPTOR.message_types_by_name['UpdatePlayerStateResponse'] = _UPDATEPLAYERSTATERESPONSE
 DESCRIPTOR.message_types_by_name['HitPlayerRequest'] = _HITPLAYERREQUEST
 DESCRIPTOR.message_types_by_name['HitPlayerResponse'] = _HITPLAYERRESPONSE
+DESCRIPTOR.message_types_by_name['BackupRequest'] = _BACKUPREQUEST
+DESCRIPTOR.message_types_by_name['BackupResponse'] = _BACKUPRESPONSE
+DESCRIPTOR.message_types_by_name['PlaceStructureRequest'] = _PLACESTRUCTUREREQUEST
+DESCRIPTOR.message_types_by_name['Voxel'] = _VOXEL
+DESCRIPTOR.message_types_by_name['PlaceStructureResponse'] = _PLACESTRUCTURERESPONSE
+DESCRIPTOR.message_types_by_name['GetSunElevationRequest'] = _GETSUNELEVATIONREQUEST
+DESCRIPTOR.message_types_by_name['GetSunElevationResponse'] = _GETSUNELEVATIONRESPONSE
 DESCRIPTOR.message_types_by_name['CellMaterialRequest'] = _CELLMATERIALREQUEST
 DESCRIPTOR.message_types_by_name['CellMaterialResponse'] = _CELLMATERIALRESPONSE
 DESCRIPTOR.enum_types_by_name['Material'] = _MATERIAL
+DESCRIPTOR.enum_types_by_name['Biome'] = _BIOME
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
 
 GetPlanetsRequest = _reflection.GeneratedProtocolMessageType('GetPlanetsRequest', (_message.Message,), dict(
@@ -1240,6 +1762,13 @@ PlanetGeometry = _reflection.GeneratedProtocolMessageType('PlanetGeometry', (_me
     # @@protoc_insertion_point(class_scope:govox.PlanetGeometry.MaterialRow)
     ))
   ,
+
+  BiomeRow = _reflection.GeneratedProtocolMessageType('BiomeRow', (_message.Message,), dict(
+    DESCRIPTOR = _PLANETGEOMETRY_BIOMEROW,
+    __module__ = 'govox_pb2'
+    # @@protoc_insertion_point(class_scope:govox.PlanetGeometry.BiomeRow)
+    ))
+  ,
   DESCRIPTOR = _PLANETGEOMETRY,
   __module__ = 'govox_pb2'
   # @@protoc_insertion_point(class_scope:govox.PlanetGeometry)
@@ -1247,6 +1776,7 @@ PlanetGeometry = _reflection.GeneratedProtocolMessageType('PlanetGeometry', (_me
 _sym_db.RegisterMessage(PlanetGeometry)
 _sym_db.RegisterMessage(PlanetGeometry.AltitudeRow)
 _sym_db.RegisterMessage(PlanetGeometry.MaterialRow)
+_sym_db.RegisterMessage(PlanetGeometry.BiomeRow)
 
 SetCellMaterialRequest = _reflection.GeneratedProtocolMessageType('SetCellMaterialRequest', (_message.Message,), dict(
   DESCRIPTOR = _SETCELLMATERIALREQUEST,
@@ -1325,6 +1855,55 @@ HitPlayerResponse = _reflection.GeneratedProtocolMessageType('HitPlayerResponse'
   ))
 _sym_db.RegisterMessage(HitPlayerResponse)
 
+BackupRequest = _reflection.GeneratedProtocolMessageType('BackupRequest', (_message.Message,), dict(
+  DESCRIPTOR = _BACKUPREQUEST,
+  __module__ = 'govox_pb2'
+  # @@protoc_insertion_point(class_scope:govox.BackupRequest)
+  ))
+_sym_db.RegisterMessage(BackupRequest)
+
+BackupResponse = _reflection.GeneratedProtocolMessageType('BackupResponse', (_message.Message,), dict(
+  DESCRIPTOR = _BACKUPRESPONSE,
+  __module__ = 'govox_pb2'
+  # @@protoc_insertion_point(class_scope:govox.BackupResponse)
+  ))
+_sym_db.RegisterMessage(BackupResponse)
+
+PlaceStructureRequest = _reflection.GeneratedProtocolMessageType('PlaceStructureRequest', (_message.Message,), dict(
+  DESCRIPTOR = _PLACESTRUCTUREREQUEST,
+  __module__ = 'govox_pb2'
+  # @@protoc_insertion_point(class_scope:govox.PlaceStructureRequest)
+  ))
+_sym_db.RegisterMessage(PlaceStructureRequest)
+
+Voxel = _reflection.GeneratedProtocolMessageType('Voxel', (_message.Message,), dict(
+  DESCRIPTOR = _VOXEL,
+  __module__ = 'govox_pb2'
+  # @@protoc_insertion_point(class_scope:govox.Voxel)
+  ))
+_sym_db.RegisterMessage(Voxel)
+
+PlaceStructureResponse = _reflection.GeneratedProtocolMessageType('PlaceStructureResponse', (_message.Message,), dict(
+  DESCRIPTOR = _PLACESTRUCTURERESPONSE,
+  __module__ = 'govox_pb2'
+  # @@protoc_insertion_point(class_scope:govox.PlaceStructureResponse)
+  ))
+_sym_db.RegisterMessage(PlaceStructureResponse)
+
+GetSunElevationRequest = _reflection.GeneratedProtocolMessageType('GetSunElevationRequest', (_message.Message,), dict(
+  DESCRIPTOR = _GETSUNELEVATIONREQUEST,
+  __module__ = 'govox_pb2'
+  # @@protoc_insertion_point(class_scope:govox.GetSunElevationRequest)
+  ))
+_sym_db.RegisterMessage(GetSunElevationRequest)
+
+GetSunElevationResponse = _reflection.GeneratedProtocolMessageType('GetSunElevationResponse', (_message.Message,), dict(
+  DESCRIPTOR = _GETSUNELEVATIONRESPONSE,
+  __module__ = 'govox_pb2'
+  # @@protoc_insertion_point(class_scope:govox.GetSunElevationResponse)
+  ))
+_sym_db.RegisterMessage(GetSunElevationResponse)
+
 CellMaterialRequest = _reflection.GeneratedProtocolMessageType('CellMaterialRequest', (_message.Message,), dict(
   DESCRIPTOR = _CELLMATERIALREQUEST,
   __module__ = 'govox_pb2'
@@ -1347,8 +1926,8 @@ _GOVOX = _descriptor.ServiceDescriptor(
   file=DESCRIPTOR,
   index=0,
   serialized_options=None,
-  serialized_start=1862,
-  serialized_end=2394,
+  serialized_start=2940,
+  serialized_end=3694,
   methods=[
   _descriptor.MethodDescriptor(
     name='GetPlanets',
@@ -1413,6 +1992,33 @@ _GOVOX = _descriptor.ServiceDescriptor(
     output_type=_HITPLAYERRESPONSE,
     serialized_options=None,
   ),
+  _descriptor.MethodDescriptor(
+    name='Backup',
+    full_name='govox.Govox.Backup',
+    index=7,
+    containing_service=None,
+    input_type=_BACKUPREQUEST,
+    output_type=_BACKUPRESPONSE,
+    serialized_options=None,
+  ),
+  _descriptor.MethodDescriptor(
+    name='PlaceStructure',
+    full_name='govox.Govox.PlaceStructure',
+    index=8,
+    containing_service=None,
+    input_type=_PLACESTRUCTUREREQUEST,
+    output_type=_PLACESTRUCTURERESPONSE,
+    serialized_options=None,
+  ),
+  _descriptor.MethodDescriptor(
+    name='GetSunElevation',
+    full_name='govox.Govox.GetSunElevation',
+    index=9,
+    containing_service=None,
+    input_type=_GETSUNELEVATIONREQUEST,
+    output_type=_GETSUNELEVATIONRESPONSE,
+    serialized_options=None,
+  ),
 ])
 _sym_db.RegisterServiceDescriptor(_GOVOX)
 
@@ -1425,8 +2031,8 @@ _GENERATOR = _descriptor.ServiceDescriptor(
   file=DESCRIPTOR,
   index=1,
   serialized_options=None,
-  serialized_start=2396,
-  serialized_end=2482,
+  serialized_start=3696,
+  serialized_end=3782,
   methods=[
   _descriptor.MethodDescriptor(
     name='CellMaterial',
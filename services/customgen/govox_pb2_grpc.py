@@ -49,6 +49,21 @@ class GovoxStub(object):
         request_serializer=govox__pb2.HitPlayerRequest.SerializeToString,
         response_deserializer=govox__pb2.HitPlayerResponse.FromString,
         )
+    self.Backup = channel.unary_unary(
+        '/govox.Govox/Backup',
+        request_serializer=govox__pb2.BackupRequest.SerializeToString,
+        response_deserializer=govox__pb2.BackupResponse.FromString,
+        )
+    self.PlaceStructure = channel.unary_unary(
+        '/govox.Govox/PlaceStructure',
+        request_serializer=govox__pb2.PlaceStructureRequest.SerializeToString,
+        response_deserializer=govox__pb2.PlaceStructureResponse.FromString,
+        )
+    self.GetSunElevation = channel.unary_unary(
+        '/govox.Govox/GetSunElevation',
+        request_serializer=govox__pb2.GetSunElevationRequest.SerializeToString,
+        response_deserializer=govox__pb2.GetSunElevationResponse.FromString,
+        )
 
 
 class GovoxServicer(object):
@@ -104,6 +119,27 @@ class GovoxServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def Backup(self, request, context):
+    # missing associated documentation comment in .proto file
+    pass
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def PlaceStructure(self, request, context):
+    # missing associated documentation comment in .proto file
+    pass
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def GetSunElevation(self, request, context):
+    # missing associated documentation comment in .proto file
+    pass
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
 
 def add_GovoxServicer_to_server(servicer, server):
   rpc_method_handlers = {
@@ -142,6 +178,21 @@ def add_GovoxServicer_to_server(servicer, server):
           request_deserializer=govox__pb2.HitPlayerRequest.FromString,
           response_serializer=govox__pb2.HitPlayerResponse.SerializeToString,
       ),
+      'Backup': grpc.unary_unary_rpc_method_handler(
+          servicer.Backup,
+          request_deserializer=govox__pb2.BackupRequest.FromString,
+          response_serializer=govox__pb2.BackupResponse.SerializeToString,
+      ),
+      'PlaceStructure': grpc.unary_unary_rpc_method_handler(
+          servicer.PlaceStructure,
+          request_deserializer=govox__pb2.PlaceStructureRequest.FromString,
+          response_serializer=govox__pb2.PlaceStructureResponse.SerializeToString,
+      ),
+      'GetSunElevation': grpc.unary_unary_rpc_method_handler(
+          servicer.GetSunElevation,
+          request_deserializer=govox__pb2.GetSunElevationRequest.FromString,
+          response_serializer=govox__pb2.GetSunElevationResponse.SerializeToString,
+      ),
   }
   generic_handler = grpc.method_handlers_generic_handler(
       'govox.Govox', rpc_method_handlers)
@@ -27,6 +27,15 @@ class BuildorbPlugin():
         YELLOW_BLOCK = pb2.YELLOW_BLOCK
         YELLOW_SAND = pb2.YELLOW_SAND
         WATER = pb2.WATER
+        BLUE_WOOD = pb2.BLUE_WOOD
+        GREEN_WOOD = pb2.GREEN_WOOD
+        PURPLE_WOOD = pb2.PURPLE_WOOD
+        YELLOW_WOOD = pb2.YELLOW_WOOD
+        BLUE_LEAVES = pb2.BLUE_LEAVES
+        COAL_ORE = pb2.COAL_ORE
+        IRON_ORE = pb2.IRON_ORE
+        GOLD_ORE = pb2.GOLD_ORE
+        CRYSTAL_ORE = pb2.CRYSTAL_ORE
 
     def __init__(self):
         self.channel = grpc.insecure_channel('localhost:50051')